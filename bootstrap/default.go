@@ -0,0 +1,34 @@
+package bootstrap
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2"
+	"github.com/go-kratos/kratos/v2/config"
+)
+
+var defaultHooks = NewHooks()
+
+// Register adds a hook to the process-wide registry used by subsystems.
+func Register(hook *Hook) {
+	defaultHooks.Register(hook)
+}
+
+func OnStart(name string, fn func(ctx context.Context) error, opts ...HookOption) {
+	defaultHooks.OnStart(name, fn, opts...)
+}
+
+func OnStop(name string, fn func(ctx context.Context) error, opts ...HookOption) {
+	defaultHooks.OnStop(name, fn, opts...)
+}
+
+func OnConfigChange(name, key string, fn func(key string, value config.Value), opts ...HookOption) {
+	defaultHooks.OnConfigChange(name, key, fn, opts...)
+}
+
+func Options() []kratos.Option {
+	return defaultHooks.Options()
+}
+
+func WatchConfig(c config.Config) error {
+	return defaultHooks.WatchConfig(c)
+}
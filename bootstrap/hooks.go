@@ -0,0 +1,182 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kratos/kratos/v2"
+	"github.com/go-kratos/kratos/v2/config"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultHookTimeout = 30 * time.Second
+)
+
+// Hook is a subsystem's participation in the application lifecycle. Hooks start in
+// ascending Order (registration order for equal orders) and stop in reverse.
+type Hook struct {
+	Name           string
+	Order          int
+	Timeout        time.Duration
+	OnStart        func(ctx context.Context) error
+	OnStop         func(ctx context.Context) error
+	ConfigKey      string
+	OnConfigChange func(key string, value config.Value)
+}
+
+type HookOption func(h *Hook)
+
+func WithOrder(order int) HookOption {
+	return func(h *Hook) {
+		h.Order = order
+	}
+}
+
+func WithTimeout(timeout time.Duration) HookOption {
+	return func(h *Hook) {
+		h.Timeout = timeout
+	}
+}
+
+type Hooks struct {
+	hooks   []*Hook
+	started []*Hook
+	m       *sync.Mutex
+}
+
+func NewHooks() *Hooks {
+	return &Hooks{
+		m: &sync.Mutex{},
+	}
+}
+
+func (h *Hooks) Register(hook *Hook) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+func (h *Hooks) OnStart(name string, fn func(ctx context.Context) error, opts ...HookOption) {
+	h.Register(newHook(&Hook{Name: name, OnStart: fn}, opts...))
+}
+
+func (h *Hooks) OnStop(name string, fn func(ctx context.Context) error, opts ...HookOption) {
+	h.Register(newHook(&Hook{Name: name, OnStop: fn}, opts...))
+}
+
+func (h *Hooks) OnConfigChange(name, key string, fn func(key string, value config.Value), opts ...HookOption) {
+	h.Register(newHook(&Hook{Name: name, ConfigKey: key, OnConfigChange: fn}, opts...))
+}
+
+// Options wires the hooks into a kratos app: start hooks run before the servers start
+// and stop hooks after they stopped.
+func (h *Hooks) Options() []kratos.Option {
+	return []kratos.Option{
+		kratos.BeforeStart(h.Start),
+		kratos.AfterStop(h.Stop),
+	}
+}
+
+// Start runs the start hooks in order. When one fails, the hooks already started are stopped.
+func (h *Hooks) Start(ctx context.Context) error {
+	h.m.Lock()
+	defer h.m.Unlock()
+	for _, hook := range h.sorted() {
+		if hook.OnStart != nil {
+			if err := run(ctx, hook, hook.OnStart); err != nil {
+				stopErr := h.stop(ctx)
+				return errors.Join(fmt.Errorf("start hook <%s> error -> %w", hook.Name, err), stopErr)
+			}
+		}
+		h.started = append(h.started, hook)
+	}
+	return nil
+}
+
+// Stop runs the stop hooks of the started hooks in reverse order and reports all failures.
+func (h *Hooks) Stop(ctx context.Context) error {
+	h.m.Lock()
+	defer h.m.Unlock()
+	return h.stop(ctx)
+}
+
+// WatchConfig dispatches changes of the watched keys of c to the config change hooks.
+func (h *Hooks) WatchConfig(c config.Config) error {
+	h.m.Lock()
+	hooks := h.sorted()
+	h.m.Unlock()
+	observers := map[string][]*Hook{}
+	var keys []string
+	for _, hook := range hooks {
+		if hook.OnConfigChange == nil || hook.ConfigKey == "" {
+			continue
+		}
+		if _, ok := observers[hook.ConfigKey]; !ok {
+			keys = append(keys, hook.ConfigKey)
+		}
+		observers[hook.ConfigKey] = append(observers[hook.ConfigKey], hook)
+	}
+	for _, key := range keys {
+		watchers := observers[key]
+		if err := c.Watch(key, func(key string, value config.Value) {
+			for _, hook := range watchers {
+				hook.OnConfigChange(key, value)
+			}
+		}); err != nil {
+			return fmt.Errorf("watch config key %s error -> %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (h *Hooks) stop(ctx context.Context) error {
+	var errs []error
+	for i := len(h.started) - 1; i >= 0; i-- {
+		hook := h.started[i]
+		if hook.OnStop == nil {
+			continue
+		}
+		if err := run(ctx, hook, hook.OnStop); err != nil {
+			errs = append(errs, fmt.Errorf("stop hook <%s> error -> %w", hook.Name, err))
+		}
+	}
+	h.started = nil
+	return errors.Join(errs...)
+}
+
+func (h *Hooks) sorted() []*Hook {
+	hooks := slices.Clone(h.hooks)
+	slices.SortStableFunc(hooks, func(a, b *Hook) int {
+		return a.Order - b.Order
+	})
+	return hooks
+}
+
+func newHook(hook *Hook, opts ...HookOption) *Hook {
+	for _, opt := range opts {
+		opt(hook)
+	}
+	return hook
+}
+
+func run(ctx context.Context, hook *Hook, fn func(ctx context.Context) error) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,121 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// knownModules maps dependency module paths to the subsystem backend they provide.
+var knownModules = map[string]Feature{
+	"go.etcd.io/etcd/client/v3":           {Subsystem: "registry", Backend: "etcd"},
+	"gorm.io/driver/mysql":                {Subsystem: "db", Backend: "mysql"},
+	"gorm.io/driver/postgres":             {Subsystem: "db", Backend: "postgres"},
+	"gorm.io/driver/sqlite":               {Subsystem: "db", Backend: "sqlite"},
+	"gorm.io/driver/sqlserver":            {Subsystem: "db", Backend: "sqlserver"},
+	"github.com/segmentio/kafka-go":       {Subsystem: "broker", Backend: "kafka"},
+	"github.com/IBM/sarama":               {Subsystem: "broker", Backend: "kafka"},
+	"github.com/rabbitmq/amqp091-go":      {Subsystem: "broker", Backend: "rabbitmq"},
+	"github.com/nats-io/nats.go":          {Subsystem: "broker", Backend: "nats"},
+	"github.com/redis/go-redis/v9":        {Subsystem: "cache", Backend: "redis"},
+	"go.opentelemetry.io/otel/sdk":        {Subsystem: "tracing", Backend: "otel"},
+	"go.opentelemetry.io/otel/sdk/metric": {Subsystem: "metrics", Backend: "otel"},
+}
+
+type Feature struct {
+	Subsystem string `json:"subsystem"`
+	Backend   string `json:"backend"`
+}
+
+type Info struct {
+	Path      string    `json:"path"`
+	Version   string    `json:"version"`
+	GoVersion string    `json:"go_version"`
+	Compiled  []Feature `json:"compiled"`
+	Enabled   []Feature `json:"enabled"`
+}
+
+var (
+	enabled []Feature
+	m       = &sync.Mutex{}
+)
+
+// Enable records that a backend of a subsystem is in use, e.g. Enable("registry", "etcd")
+// or Enable("middleware", "recovery").
+func Enable(subsystem, backend string) {
+	m.Lock()
+	defer m.Unlock()
+	f := Feature{Subsystem: subsystem, Backend: strings.ToLower(backend)}
+	if !slices.Contains(enabled, f) {
+		enabled = append(enabled, f)
+	}
+}
+
+func Get() *Info {
+	info := &Info{GoVersion: runtime.Version()}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.Path = bi.Main.Path
+		info.Version = bi.Main.Version
+		for _, dep := range bi.Deps {
+			if f, ok := knownModules[dep.Path]; ok && !slices.Contains(info.Compiled, f) {
+				info.Compiled = append(info.Compiled, f)
+			}
+		}
+	}
+	m.Lock()
+	info.Enabled = slices.Clone(enabled)
+	m.Unlock()
+	sortFeatures(info.Compiled)
+	sortFeatures(info.Enabled)
+	return info
+}
+
+// Handler serves the feature matrix as JSON.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Get())
+	})
+}
+
+// RegisterMetrics reports every compiled and enabled feature as a zero_feature_info gauge
+// with subsystem, backend and state labels.
+func RegisterMetrics(meter metric.Meter) error {
+	_, err := meter.Int64ObservableGauge(
+		"zero_feature_info",
+		metric.WithDescription("Subsystem backends compiled into and enabled in the service."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			info := Get()
+			observe := func(state string, features []Feature) {
+				for _, f := range features {
+					o.Observe(1, metric.WithAttributes(
+						attribute.String("subsystem", f.Subsystem),
+						attribute.String("backend", f.Backend),
+						attribute.String("state", state),
+					))
+				}
+			}
+			observe("compiled", info.Compiled)
+			observe("enabled", info.Enabled)
+			return nil
+		}),
+	)
+	return err
+}
+
+func sortFeatures(features []Feature) {
+	sort.Slice(features, func(i, j int) bool {
+		if features[i].Subsystem != features[j].Subsystem {
+			return features[i].Subsystem < features[j].Subsystem
+		}
+		return features[i].Backend < features[j].Backend
+	})
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/cocosip/zero/config"
+	"github.com/cocosip/zero/registry"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var callCommand = &command{
+	name:  "call",
+	usage: "call [-config file] [-data json] [-X method] <service> <pkg.Service/Method | /http/path>",
+	run:   runCall,
+}
+
+func runCall(args []string) error {
+	fs := flag.NewFlagSet("call", flag.ExitOnError)
+	path := fs.String("config", "configs", "config file or directory holding the registry option")
+	data := fs.String("data", "{}", "request body as JSON")
+	method := fs.String("X", "", "HTTP method, defaults to GET without -data and POST with it")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout of the call")
+	_ = fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("expected a service name and a method")
+	}
+
+	bc, err := config.Load(*path)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	opt := &registry.RegistryOption{}
+	if bc.GetRegistry() != nil {
+		opt = proto.Clone(bc.GetRegistry()).(*registry.RegistryOption)
+	}
+	// call only discovers services, it must never write to the registry
+	if opt.GetLocal() != nil {
+		opt.Local.ReadOnly = true
+	}
+	reg := registry.New(opt)
+	if strings.HasPrefix(fs.Arg(1), "/") {
+		httpMethod := *method
+		if httpMethod == "" {
+			httpMethod = http.MethodGet
+			if isFlagSet(fs, "data") {
+				httpMethod = http.MethodPost
+			}
+		}
+		return callHTTP(ctx, reg, fs.Arg(0), httpMethod, fs.Arg(1), *data)
+	}
+	return callGRPC(ctx, reg, bc, fs.Arg(0), fs.Arg(1), *data)
+}
+
+func callGRPC(ctx context.Context, reg registry.FactoryInterface, bc *config.Bootstrap, service, fullMethod, data string) error {
+	logger := log.NewFilter(log.DefaultLogger, log.FilterLevel(log.LevelError))
+	factory := registry.NewClientFactory(reg, logger, bc.GetLog())
+	cli, closer, err := factory.CreateNewClient(service, registry.ClientCreateFunc(func(conn *grpc.ClientConn) (interface{}, error) {
+		return conn, nil
+	}))
+	if err != nil {
+		return err
+	}
+	defer closer()
+	conn := cli.(*grpc.ClientConn)
+
+	svcName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return fmt.Errorf("method %s must look like pkg.Service/Method", fullMethod)
+	}
+	md, err := resolveMethod(ctx, conn, svcName, methodName)
+	if err != nil {
+		return err
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return fmt.Errorf("streaming method %s is not supported", fullMethod)
+	}
+	in := dynamicpb.NewMessage(md.Input())
+	if err = protojson.Unmarshal([]byte(data), in); err != nil {
+		return fmt.Errorf("decode request error -> %w", err)
+	}
+	out := dynamicpb.NewMessage(md.Output())
+	if err = conn.Invoke(ctx, "/"+svcName+"/"+methodName, in, out); err != nil {
+		return err
+	}
+	b, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(out)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+// resolveMethod loads the descriptor of a method through the server reflection service.
+func resolveMethod(ctx context.Context, conn *grpc.ClientConn, service, method string) (protoreflect.MethodDescriptor, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	fdps := map[string]*descriptorpb.FileDescriptorProto{}
+	var order []string
+	request := &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}
+	for request != nil {
+		if err = stream.Send(request); err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if e := resp.GetErrorResponse(); e != nil {
+			return nil, fmt.Errorf("reflection error -> %s", e.GetErrorMessage())
+		}
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fdp := &descriptorpb.FileDescriptorProto{}
+			if err = proto.Unmarshal(b, fdp); err != nil {
+				return nil, err
+			}
+			if _, ok := fdps[fdp.GetName()]; !ok {
+				fdps[fdp.GetName()] = fdp
+				order = append(order, fdp.GetName())
+			}
+		}
+		request = nil
+		for _, name := range order {
+			for _, dep := range fdps[name].GetDependency() {
+				if _, ok := fdps[dep]; !ok {
+					request = &rpb.ServerReflectionRequest{
+						MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+					}
+					break
+				}
+			}
+			if request != nil {
+				break
+			}
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, name := range order {
+		set.File = append(set.File, fdps[name])
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("service %s has no method %s", service, method)
+	}
+	return md, nil
+}
+
+func callHTTP(ctx context.Context, reg registry.FactoryInterface, service, method, path, data string) error {
+	dis, err := reg.GetDiscovery()
+	if err != nil {
+		return err
+	}
+	instances, err := dis.GetService(ctx, service)
+	if err != nil {
+		return err
+	}
+	var base *url.URL
+	for _, instance := range instances {
+		for _, endpoint := range instance.Endpoints {
+			if u, err := url.Parse(endpoint); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+				base = u
+				break
+			}
+		}
+		if base != nil {
+			break
+		}
+	}
+	if base == nil {
+		return fmt.Errorf("service %s has no http endpoint", service)
+	}
+
+	var body io.Reader
+	if method != http.MethodGet && method != http.MethodHead {
+		body = strings.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, base.JoinPath(path).String(), body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	fmt.Fprintf(os.Stderr, "%s %s -> %s\n", method, req.URL.String(), resp.Status)
+	_, err = io.Copy(os.Stdout, resp.Body)
+	return err
+}
+
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
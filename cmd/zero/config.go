@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/cocosip/zero/config"
+	"io"
+	"os"
+)
+
+var configCommand = &command{
+	name:  "config",
+	usage: "config init [-o file] | config validate <file>",
+	run:   runConfig,
+}
+
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand, expected init or validate")
+	}
+	switch args[0] {
+	case "init":
+		return runConfigInit(args[1:])
+	case "validate":
+		return runConfigValidate(args[1:])
+	default:
+		return fmt.Errorf("unknown subcommand %s", args[0])
+	}
+}
+
+func runConfigInit(args []string) error {
+	fs := flag.NewFlagSet("config init", flag.ExitOnError)
+	output := fs.String("o", "", "write the template to this file instead of stdout")
+	_ = fs.Parse(args)
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return config.WriteTemplate(w, config.Default())
+}
+
+func runConfigValidate(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected exactly one config file")
+	}
+	bc, err := config.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if err = config.Validate(bc); err != nil {
+		return err
+	}
+	fmt.Printf("%s is valid\n", args[0])
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/cocosip/zero/config"
+	"github.com/cocosip/zero/registry"
+	kregistry "github.com/go-kratos/kratos/v2/registry"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var registryCommand = &command{
+	name:  "registry",
+	usage: "registry migrate -config file -from provider -to provider -services a,b [-sync interval]",
+	run:   runRegistry,
+}
+
+func runRegistry(args []string) error {
+	if len(args) == 0 || args[0] != "migrate" {
+		return fmt.Errorf("missing subcommand, expected migrate")
+	}
+	return runRegistryMigrate(args[1:])
+}
+
+func runRegistryMigrate(args []string) error {
+	fs := flag.NewFlagSet("registry migrate", flag.ExitOnError)
+	path := fs.String("config", "configs", "config file or directory holding the registry option")
+	from := fs.String("from", "", "source registry provider")
+	to := fs.String("to", "", "target registry provider")
+	services := fs.String("services", "", "comma separated service names to migrate")
+	sync := fs.Duration("sync", 0, "keep syncing at this interval until interrupted")
+	_ = fs.Parse(args)
+	if *from == "" || *to == "" || *services == "" {
+		return fmt.Errorf("-from, -to and -services are required")
+	}
+
+	bc, err := config.Load(*path)
+	if err != nil {
+		return err
+	}
+	src, err := newDiscovery(bc, *from)
+	if err != nil {
+		return err
+	}
+	dst, err := newRegistrar(bc, *to)
+	if err != nil {
+		return err
+	}
+	m := registry.NewMigrator(src, dst, strings.Split(*services, ",")...)
+
+	if *sync <= 0 {
+		registered, removed, err := m.Copy(context.Background())
+		fmt.Printf("registered %d, removed %d\n", registered, removed)
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	m.Sync(ctx, *sync, func(registered, removed int, err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s sync error -> %s\n", time.Now().Format(time.RFC3339), err.Error())
+			return
+		}
+		if registered > 0 || removed > 0 {
+			fmt.Printf("%s registered %d, removed %d\n", time.Now().Format(time.RFC3339), registered, removed)
+		}
+	})
+	return nil
+}
+
+func newFactory(bc *config.Bootstrap, provider string) registry.FactoryInterface {
+	opt := &registry.RegistryOption{}
+	if bc.GetRegistry() != nil {
+		opt = proto.Clone(bc.GetRegistry()).(*registry.RegistryOption)
+	}
+	opt.Provider = provider
+	return registry.New(opt)
+}
+
+func newDiscovery(bc *config.Bootstrap, provider string) (kregistry.Discovery, error) {
+	return newFactory(bc, provider).GetDiscovery()
+}
+
+func newRegistrar(bc *config.Bootstrap, provider string) (kregistry.Registrar, error) {
+	return newFactory(bc, provider).GetRegister()
+}
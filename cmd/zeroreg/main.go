@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"github.com/cocosip/zero/contrib/registry/local"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"google.golang.org/grpc/metadata"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const usage = `usage: zeroreg [-addr host:port] <command> [arguments]
+
+commands:
+  list                                    list service names
+  get <name>                              show the instances of a service
+  add [-id id] [-version v] <name> <endpoint>...
+                                          register an instance
+  rm <name> <id>                          deregister an instance
+  watch [-interval 1s] <name>             print the instances whenever they change
+  snapshot                                print every instance
+`
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9000", "address of the RegistryAdmin gRPC service")
+	timeout := flag.Duration("timeout", 5*time.Second, "timeout of each call")
+	token := flag.String("token", os.Getenv("ZERO_REGISTRY_TOKEN"), "bearer token authenticating to registries enforcing ACLs")
+	caFile := flag.String("ca", "", "CA certificate verifying the server, enables TLS")
+	certFile := flag.String("cert", "", "client certificate authenticating to registries enforcing ACLs over mTLS")
+	keyFile := flag.String("key", "", "key of the client certificate")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := []grpc.ClientOption{grpc.WithEndpoint(*addr), grpc.WithTimeout(*timeout)}
+	dial := grpc.DialInsecure
+	if *caFile != "" || *certFile != "" {
+		tlsConfig, err := clientTLS(*caFile, *certFile, *keyFile)
+		if err != nil {
+			fail(err)
+		}
+		opts = append(opts, grpc.WithTLSConfig(tlsConfig))
+		dial = grpc.Dial
+	}
+	conn, err := dial(context.Background(), opts...)
+	if err != nil {
+		fail(err)
+	}
+	defer conn.Close()
+	cli := &client{admin: local.NewRegistryAdminClient(conn), timeout: *timeout, token: *token}
+
+	args := flag.Args()
+	switch args[0] {
+	case "list":
+		err = cli.list()
+	case "get":
+		err = cli.get(args[1:])
+	case "add":
+		err = cli.add(args[1:])
+	case "rm":
+		err = cli.rm(args[1:])
+	case "watch":
+		err = cli.watch(args[1:])
+	case "snapshot":
+		err = cli.snapshot()
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// clientTLS verifies the server with the CA, or the system roots without one, and
+// presents the client certificate if given.
+func clientTLS(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", caFile)
+		}
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+type client struct {
+	admin   local.RegistryAdminClient
+	timeout time.Duration
+	token   string
+}
+
+func (c *client) context() (context.Context, context.CancelFunc) {
+	ctx := context.Background()
+	if c.token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func (c *client) list() error {
+	ctx, cancel := c.context()
+	defer cancel()
+	reply, err := c.admin.ListServices(ctx, &local.ListServicesRequest{})
+	if err != nil {
+		return err
+	}
+	for _, name := range reply.GetServices() {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+func (c *client) get(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("get expects a service name")
+	}
+	instances, err := c.instances(args[0])
+	if err != nil {
+		return err
+	}
+	printInstances(instances)
+	return nil
+}
+
+func (c *client) add(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	id := fs.String("id", "", "instance id, defaults to the name")
+	version := fs.String("version", "", "instance version")
+	_ = fs.Parse(args)
+	if fs.NArg() < 2 {
+		return fmt.Errorf("add expects a service name and at least one endpoint")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.admin.Register(ctx, &local.RegisterRequest{Instance: &local.Instance{
+		Id:        *id,
+		Name:      fs.Arg(0),
+		Version:   *version,
+		Endpoints: fs.Args()[1:],
+	}})
+	return err
+}
+
+func (c *client) rm(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("rm expects a service name and an instance id")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.admin.Deregister(ctx, &local.DeregisterRequest{Name: args[0], Id: args[1]})
+	return err
+}
+
+func (c *client) watch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	interval := fs.Duration("interval", time.Second, "poll interval")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("watch expects a service name")
+	}
+	var last string
+	for {
+		instances, err := c.instances(fs.Arg(0))
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if current := fingerprint(instances); current != last {
+			fmt.Printf("--- %s\n", time.Now().Format(time.RFC3339))
+			printInstances(instances)
+			last = current
+		}
+		time.Sleep(*interval)
+	}
+}
+
+func (c *client) snapshot() error {
+	ctx, cancel := c.context()
+	defer cancel()
+	reply, err := c.admin.Snapshot(ctx, &local.SnapshotRequest{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("authority: %s\n", reply.GetAuthority())
+	printInstances(reply.GetInstances())
+	return nil
+}
+
+func (c *client) instances(name string) ([]*local.Instance, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	reply, err := c.admin.GetService(ctx, &local.GetServiceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetInstances(), nil
+}
+
+func printInstances(instances []*local.Instance) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tID\tVERSION\tENDPOINTS\tUPDATED")
+	for _, in := range instances {
+		updated := "-"
+		if in.GetTimestamp() > 0 {
+			updated = time.Unix(in.GetTimestamp(), 0).Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", in.GetName(), in.GetId(), in.GetVersion(), strings.Join(in.GetEndpoints(), ","), updated)
+	}
+	_ = w.Flush()
+}
+
+func fingerprint(instances []*local.Instance) string {
+	lines := make([]string, 0, len(instances))
+	for _, in := range instances {
+		lines = append(lines, in.GetId()+"|"+in.GetVersion()+"|"+strings.Join(in.GetEndpoints(), ","))
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n")
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "zeroreg: %s\n", err.Error())
+	os.Exit(1)
+}
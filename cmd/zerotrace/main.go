@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/cocosip/zero/tracing"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	endpoint := flag.String("endpoint", "http://localhost:4318", "OTLP/HTTP endpoint of the collector")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of each request")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: zerotrace [-endpoint url] file...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	for _, name := range flag.Args() {
+		n, err := replayFile(client, name, *endpoint)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name, err.Error())
+			os.Exit(1)
+		}
+		fmt.Printf("%s: %d batches sent\n", name, n)
+	}
+}
+
+func replayFile(client *http.Client, name, endpoint string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return tracing.Replay(context.Background(), f, endpoint, client)
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	zerolog "github.com/cocosip/zero/log"
+	"github.com/cocosip/zero/middleware/cors"
+	"github.com/cocosip/zero/registry"
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteTemplate writes bc as YAML, every field preceded by its documentation,
+// so deployments can start from a complete template.
+func WriteTemplate(w io.Writer, bc *Bootstrap) error {
+	var sb strings.Builder
+	writeMessage(&sb, bc.ProtoReflect(), 0)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// Load reads a Bootstrap from a config file or directory.
+func Load(path string) (*Bootstrap, error) {
+	c := kconfig.New(kconfig.WithSource(file.NewSource(path)))
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		return nil, err
+	}
+	bc := &Bootstrap{}
+	if err := c.Scan(bc); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// Validate reports every invalid setting of bc.
+func Validate(bc *Bootstrap) error {
+	var errs []error
+	if level := bc.GetLog().GetLevel(); level != "" && log.ParseLevel(level).String() != strings.ToUpper(level) {
+		errs = append(errs, fmt.Errorf("log.level: unknown level %q", level))
+	}
+	if fo := bc.GetLog().GetFileOption(); fo.GetMaxSize() < 0 || fo.GetMaxAge() < 0 || fo.GetMaxBackups() < 0 {
+		errs = append(errs, fmt.Errorf("log.file_option: sizes, ages and backups must not be negative"))
+	}
+	if _, err := zerolog.NewRedactor(bc.GetLog().GetRedact()); err != nil {
+		errs = append(errs, fmt.Errorf("log.redact: %w", err))
+	}
+	if r := bc.GetLog().GetFileOption().GetRotation(); r != "" && r != "daily" && r != "hourly" {
+		errs = append(errs, fmt.Errorf("log.file_option.rotation: unknown rotation %q", r))
+	}
+	for module, level := range bc.GetLog().GetModules() {
+		if log.ParseLevel(level).String() != strings.ToUpper(level) {
+			errs = append(errs, fmt.Errorf("log.modules.%s: unknown level %q", module, level))
+		}
+	}
+	if s := bc.GetLog().GetSampling(); s.GetInitial() < 0 || s.GetThereafter() < 0 || s.GetTick() < 0 {
+		errs = append(errs, fmt.Errorf("log.sampling: initial, thereafter and tick must not be negative"))
+	}
+	if reg := bc.GetRegistry(); reg != nil {
+		if err := registry.Validate(reg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if c := bc.GetCors(); c != nil {
+		if err := cors.ValidateConfig(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if push := bc.GetMetrics().GetPush(); push.GetEndpoint() != "" && push.GetJob() == "" {
+		errs = append(errs, fmt.Errorf("metrics.push.job: required when endpoint is set"))
+	}
+	return errors.Join(errs...)
+}
+
+func writeMessage(sb *strings.Builder, m protoreflect.Message, indent int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		pad := strings.Repeat(" ", indent)
+		if c, ok := comments[fd.FullName()]; ok {
+			fmt.Fprintf(sb, "%s# %s\n", pad, c)
+		}
+		switch {
+		case fd.IsMap():
+			mv := m.Get(fd).Map()
+			if mv.Len() == 0 {
+				fmt.Fprintf(sb, "%s%s: {}\n", pad, fd.Name())
+				continue
+			}
+			fmt.Fprintf(sb, "%s%s:\n", pad, fd.Name())
+			mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				fmt.Fprintf(sb, "%s  %s: %s\n", pad, k.String(), scalar(fd.MapValue(), v))
+				return true
+			})
+		case fd.IsList():
+			lv := m.Get(fd).List()
+			if lv.Len() == 0 {
+				fmt.Fprintf(sb, "%s%s: []\n", pad, fd.Name())
+				continue
+			}
+			fmt.Fprintf(sb, "%s%s:\n", pad, fd.Name())
+			for j := 0; j < lv.Len(); j++ {
+				if fd.Message() != nil {
+					var item strings.Builder
+					writeMessage(&item, lv.Get(j).Message(), indent+4)
+					fmt.Fprintf(sb, "%s  - %s", pad, strings.TrimLeft(item.String(), " "))
+					continue
+				}
+				fmt.Fprintf(sb, "%s  - %s\n", pad, scalar(fd, lv.Get(j)))
+			}
+		case fd.Message() != nil:
+			fmt.Fprintf(sb, "%s%s:\n", pad, fd.Name())
+			writeMessage(sb, m.Get(fd).Message(), indent+2)
+		default:
+			fmt.Fprintf(sb, "%s%s: %s\n", pad, fd.Name(), scalar(fd, m.Get(fd)))
+		}
+	}
+}
+
+func scalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return strconv.Quote(v.String())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	default:
+		return v.String()
+	}
+}
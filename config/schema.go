@@ -0,0 +1,152 @@
+package config
+
+import (
+	"github.com/cocosip/zero/cors"
+	"github.com/cocosip/zero/log"
+	"github.com/cocosip/zero/metrics"
+	"github.com/cocosip/zero/observability"
+	"github.com/cocosip/zero/registry"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"slices"
+)
+
+// comments documents the Bootstrap fields, keyed by the field's full proto name.
+var comments = map[protoreflect.FullName]string{
+	"zero.config.Bootstrap.log":           "Logging of the service.",
+	"zero.config.Bootstrap.registry":      "Service registry used for registration and discovery.",
+	"zero.config.Bootstrap.cors":          "CORS policy of the HTTP server.",
+	"zero.config.Bootstrap.metrics":       "Metrics export.",
+	"zero.config.Bootstrap.observability": "Wiring of logging, metrics and tracing.",
+
+	"zero.log.LogOption.level":                              "Minimum level: debug, info, warn, error or fatal.",
+	"zero.log.LogOption.file_option":                        "Rotating log file.",
+	"zero.log.LogOption.filter_keys":                        "Keys whose values are masked in log lines.",
+	"zero.log.LogOption.backend":                            "Logger implementation: std (default) or zap.",
+	"zero.log.LogOption.format":                             "Line format: text (key=value, default), json or console (colored, for local development).",
+	"zero.log.LogOption.dedupe_window":                      "Seconds within which identical lines are collapsed into a summary, 0 disables.",
+	"zero.log.LogOption.modules":                            "Level overrides keyed by the module field of log lines, e.g. gorm: warn.",
+	"zero.log.LogOption.loki":                               "Push log lines to Grafana Loki.",
+	"zero.log.LogOption.LokiOption.endpoint":                "Base URL of Loki, e.g. http://loki:3100.",
+	"zero.log.LogOption.LokiOption.tenant":                  "Tenant sent as X-Scope-OrgID, empty for single-tenant Loki.",
+	"zero.log.LogOption.LokiOption.batch_size":              "Maximum lines per push, 500 by default.",
+	"zero.log.LogOption.LokiOption.flush_interval":          "Seconds between pushes, 5 by default.",
+	"zero.log.LogOption.LokiOption.labels":                  "Stream labels added to service_id, service_name and service_version.",
+	"zero.log.LogOption.elasticsearch":                      "Bulk-index log records into Elasticsearch or OpenSearch.",
+	"zero.log.LogOption.ElasticsearchOption.endpoint":       "Base URL of the cluster, e.g. http://elasticsearch:9200.",
+	"zero.log.LogOption.ElasticsearchOption.index":          "Index pattern, Go time layouts in braces are expanded, logs-{2006.01.02} by default.",
+	"zero.log.LogOption.ElasticsearchOption.username":       "Basic auth user name.",
+	"zero.log.LogOption.ElasticsearchOption.password":       "Basic auth password.",
+	"zero.log.LogOption.ElasticsearchOption.batch_size":     "Maximum records per bulk request, 500 by default.",
+	"zero.log.LogOption.ElasticsearchOption.flush_interval": "Seconds between bulk requests, 5 by default.",
+	"zero.log.LogOption.ElasticsearchOption.queue_size":     "Queued records before logging blocks and then drops, 10000 by default.",
+	"zero.log.LogOption.ElasticsearchOption.max_retries":    "Retries of bulk requests failing with 429 or 5xx, 3 by default.",
+	"zero.log.LogOption.redact":                             "Masking of sensitive values in log lines and gorm SQL.",
+	"zero.log.LogOption.RedactOption.keys":                  "Keys and SQL columns whose values are masked, matched case-insensitively as substrings, e.g. password, token.",
+	"zero.log.LogOption.RedactOption.patterns":              "Regular expressions masked in values, e.g. card numbers.",
+	"zero.log.LogOption.RedactOption.mask":                  "Replacement of masked values, *** by default.",
+	"zero.log.LogOption.outputs":                            "Outputs every line is written to, stdout when empty.",
+	"zero.log.LogOption.OutputOption.type":                  "stdout, stderr, file, syslog, loki or elasticsearch.",
+	"zero.log.LogOption.OutputOption.level":                 "Minimum level of the output, the log level when empty.",
+	"zero.log.LogOption.OutputOption.format":                "Line format of the output, the log format when empty.",
+	"zero.log.LogOption.OutputOption.path":                  "File path of file outputs, address of syslog outputs.",
+	"zero.log.LogOption.OutputOption.network":               "Network of syslog outputs: udp, tcp or unixgram.",
+	"zero.log.LogOption.sampling":                           "Sampling of repeated lines of hot paths.",
+	"zero.log.LogOption.LogSamplingOption.initial":          "Lines of each level and message logged per tick before sampling, 0 disables sampling.",
+	"zero.log.LogOption.LogSamplingOption.thereafter":       "After initial, log every thereafter-th line, 0 drops the rest of the tick.",
+	"zero.log.LogOption.LogSamplingOption.tick":             "Seconds after which the counts reset, 1 by default.",
+	"zero.log.LogOption.LogSamplingOption.levels":           "Sampled levels, debug and info by default.",
+	"zero.log.LogOption.LogFileOption.max_size":             "Maximum size in megabytes before the file is rotated.",
+	"zero.log.LogOption.LogFileOption.max_age":              "Days to keep rotated files.",
+	"zero.log.LogOption.LogFileOption.max_backups":          "Maximum number of rotated files to keep.",
+	"zero.log.LogOption.LogFileOption.local_time":           "Use local time in rotated file names instead of UTC.",
+	"zero.log.LogOption.LogFileOption.compress":             "Gzip rotated files.",
+	"zero.log.LogOption.LogFileOption.rotation":             "Time-based rotation, daily or hourly, into files named after the period; empty rotates by max_size.",
+	"zero.log.LogOption.LogFileOption.stdout":               "Also write log lines to stdout.",
+
+	"zero.registry.RegistryOption.provider":                    "Registry backend: local or etcd.",
+	"zero.registry.RegistryOption.authority":                   "Authority part of discovery:// endpoints of the local registry.",
+	"zero.registry.RegistryOption.local":                       "Local in-process registry.",
+	"zero.registry.RegistryOption.etcd":                        "Etcd registry.",
+	"zero.registry.RegistryOption.LocalOption.entries":         "Static service entries, which never expire.",
+	"zero.registry.RegistryOption.LocalOption.poll_interval":   "Seconds between watcher checks.",
+	"zero.registry.RegistryOption.LocalOption.ttl":             "Seconds after which registered entries expire, 0 disables expiry.",
+	"zero.registry.RegistryOption.LocalOption.read_only":       "Reject Register and Deregister calls.",
+	"zero.registry.RegistryOption.LocalOption.healthy_only":    "Hide instances whose status is not UP from discovery.",
+	"zero.registry.RegistryOption.LocalOption.namespace":       "Namespace isolating instances, e.g. dev or staging.",
+	"zero.registry.RegistryOption.LocalOption.ordering":        "Endpoint order of GetService: none, shuffle or round_robin.",
+	"zero.registry.RegistryOption.LocalOption.Entry.id":        "Instance id, defaults to the name.",
+	"zero.registry.RegistryOption.LocalOption.Entry.name":      "Service name.",
+	"zero.registry.RegistryOption.LocalOption.Entry.version":   "Service version.",
+	"zero.registry.RegistryOption.LocalOption.Entry.endpoints": "Endpoints such as grpc://127.0.0.1:9000.",
+	"zero.registry.RegistryOption.EtcdOption.username":         "Etcd user name.",
+	"zero.registry.RegistryOption.EtcdOption.password":         "Etcd password.",
+	"zero.registry.RegistryOption.EtcdOption.endpoints":        "Etcd endpoints such as 127.0.0.1:2379.",
+
+	"zero.cors.CorsOption.origins":                "Allowed origins, * allows any origin.",
+	"zero.cors.CorsOption.methods":                "Allowed methods.",
+	"zero.cors.CorsOption.headers":                "Allowed request headers.",
+	"zero.cors.CorsOption.allow_credentials":      "Allow cookies and authorization headers.",
+	"zero.cors.CorsOption.origin_patterns":        "Regular expressions of additionally allowed origins.",
+	"zero.cors.CorsOption.max_age":                "Seconds browsers may cache preflight results, 0 omits the header.",
+	"zero.cors.CorsOption.options_passthrough":    "Pass preflight requests on to the application after writing the CORS headers.",
+	"zero.cors.CorsOption.options_success_status": "Status of answered preflight requests, 204 by default.",
+	"zero.cors.CorsOption.profiles":               "Policies replacing this one for requests to the keyed host.",
+	"zero.cors.CorsOption.origin_max_age":         "Per-origin overrides of max_age in seconds, keyed by origin rule.",
+
+	"zero.metrics.MetricsOption.push":                   "Push metrics to a Prometheus Pushgateway.",
+	"zero.metrics.MetricsOption.PushOption.endpoint":    "Pushgateway URL, empty disables pushing.",
+	"zero.metrics.MetricsOption.PushOption.job":         "Job label of the pushed metrics.",
+	"zero.metrics.MetricsOption.PushOption.grouping":    "Additional grouping labels.",
+	"zero.metrics.MetricsOption.PushOption.interval":    "Seconds between pushes.",
+	"zero.metrics.MetricsOption.PushOption.batch_size":  "Maximum metric families per request.",
+	"zero.metrics.MetricsOption.PushOption.max_retries": "Retries of a failed push.",
+	"zero.metrics.MetricsOption.PushOption.username":    "Basic auth user name.",
+	"zero.metrics.MetricsOption.PushOption.password":    "Basic auth password.",
+
+	"zero.observability.ObservabilityOption.disable_logging": "Discard log output.",
+	"zero.observability.ObservabilityOption.disable_metrics": "Use a no-op meter provider.",
+	"zero.observability.ObservabilityOption.disable_tracing": "Use a no-op tracer provider.",
+	"zero.observability.ObservabilityOption.log_file":        "Rotating log file, empty logs to stdout; ignored when log.outputs is set.",
+	"zero.observability.ObservabilityOption.trace_file":      "Rotating OTLP/JSON span file, empty keeps spans in process.",
+}
+
+func Default() *Bootstrap {
+	return &Bootstrap{
+		Log: &log.LogOption{
+			Level:   "info",
+			Backend: log.BackendStd,
+			Format:  log.FormatText,
+			FileOption: &log.LogOption_LogFileOption{
+				MaxSize:    100,
+				MaxAge:     30,
+				MaxBackups: 10,
+				LocalTime:  true,
+				Stdout:     true,
+			},
+		},
+		Registry: &registry.RegistryOption{
+			Provider:  "local",
+			Authority: "default",
+			Local: &registry.RegistryOption_LocalOption{
+				PollInterval: 1,
+			},
+			Etcd: &registry.RegistryOption_EtcdOption{
+				Endpoints: []string{"127.0.0.1:2379"},
+			},
+		},
+		Cors: &cors.CorsOption{
+			Origins: []string{"*"},
+			Methods: slices.Clone(cors.DefaultMethods),
+			Headers: slices.Clone(cors.DefaultHeaders),
+		},
+		Metrics: &metrics.MetricsOption{
+			Push: &metrics.MetricsOption_PushOption{
+				Job:        "zero",
+				Interval:   15,
+				BatchSize:  100,
+				MaxRetries: 3,
+			},
+		},
+		Observability: &observability.ObservabilityOption{},
+	}
+}
@@ -0,0 +1,22 @@
+package echocors
+
+import (
+	"github.com/cocosip/zero/middleware/cors"
+	"github.com/labstack/echo/v4"
+)
+
+// EchoMiddleware enforces the CORS options on an echo server, e.g. with the centrally
+// managed configuration: e.Use(echocors.EchoMiddleware(cors.WithCorsOption(c))).
+// Answered preflights and strict mode rejections don't reach the handler. It panics on
+// invalid options like cors.NewPolicy.
+func EchoMiddleware(opts ...cors.Option) echo.MiddlewareFunc {
+	p := cors.NewPolicy(opts...)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if p.Apply(c.Response(), c.Request()) {
+				return nil
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package echocors
+
+import (
+	"github.com/cocosip/zero/middleware/cors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEchoMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(EchoMiddleware(cors.WithAllowedOrigins("https://app.example.com"), cors.WithAllowCredentials(true)))
+	e.GET("/items", func(c echo.Context) error { return c.String(http.StatusOK, "items") })
+	e.OPTIONS("/items", func(c echo.Context) error {
+		t.Fatal("preflight reached the handler")
+		return nil
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		origin string
+		code   int
+		allow  string
+	}{
+		{"actual request", http.MethodGet, "https://app.example.com", http.StatusOK, "https://app.example.com"},
+		{"preflight", http.MethodOptions, "https://app.example.com", http.StatusNoContent, "https://app.example.com"},
+		{"foreign origin", http.MethodGet, "https://evil.example", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://api.example.com/items", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", "GET")
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("status %d, want %d", rec.Code, tt.code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.allow {
+				t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, tt.allow)
+			}
+		})
+	}
+}
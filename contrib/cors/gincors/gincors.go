@@ -0,0 +1,21 @@
+package gincors
+
+import (
+	"github.com/cocosip/zero/middleware/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware enforces the CORS options on a gin engine, e.g. with the centrally
+// managed configuration: r.Use(gincors.GinMiddleware(cors.WithCorsOption(c))).
+// Answered preflights and strict mode rejections abort the chain. It panics on invalid
+// options like cors.NewPolicy.
+func GinMiddleware(opts ...cors.Option) gin.HandlerFunc {
+	p := cors.NewPolicy(opts...)
+	return func(c *gin.Context) {
+		if p.Apply(c.Writer, c.Request) {
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package gincors
+
+import (
+	"github.com/cocosip/zero/middleware/cors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(GinMiddleware(cors.WithAllowedOrigins("https://app.example.com"), cors.WithAllowCredentials(true)))
+	r.GET("/items", func(c *gin.Context) { c.String(http.StatusOK, "items") })
+	r.OPTIONS("/items", func(c *gin.Context) { t.Fatal("preflight reached the handler") })
+
+	tests := []struct {
+		name   string
+		method string
+		origin string
+		code   int
+		allow  string
+	}{
+		{"actual request", http.MethodGet, "https://app.example.com", http.StatusOK, "https://app.example.com"},
+		{"preflight", http.MethodOptions, "https://app.example.com", http.StatusNoContent, "https://app.example.com"},
+		{"foreign origin", http.MethodGet, "https://evil.example", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://api.example.com/items", nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", "GET")
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("status %d, want %d", rec.Code, tt.code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.allow {
+				t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, tt.allow)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package local
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ACL maps an identity to the service name patterns (path.Match syntax) it may register and
+// deregister. The "*" identity applies to every caller, including anonymous ones. A nil ACL
+// allows everything.
+type ACL map[string][]string
+
+func (a ACL) Allowed(identity, service string) bool {
+	if a == nil {
+		return true
+	}
+	for _, pattern := range a.patterns(identity) {
+		if ok, _ := path.Match(pattern, service); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedAll reports whether identity may modify every service, as compaction does.
+func (a ACL) AllowedAll(identity string) bool {
+	return a == nil || slices.Contains(a.patterns(identity), "*")
+}
+
+func (a ACL) patterns(identity string) []string {
+	patterns := a["*"]
+	if identity != "" && identity != "*" {
+		patterns = append(slices.Clone(a[identity]), patterns...)
+	}
+	return patterns
+}
+
+type AdminOption func(s *AdminServer)
+
+// WithACL restricts which identities may register and deregister which services.
+func WithACL(acl ACL) AdminOption {
+	return func(s *AdminServer) {
+		s.acl = acl
+	}
+}
+
+// WithIdentityFunc overrides how the caller identity is resolved from the request context,
+// PeerCertificateIdentity by default. The function must only return identities the
+// caller proved, never ones it merely claims. HTTP requests reach it with their TLS state
+// as gRPC peer and their Authorization header as incoming metadata.
+func WithIdentityFunc(fn func(ctx context.Context) string) AdminOption {
+	return func(s *AdminServer) {
+		s.identity = fn
+	}
+}
+
+// WithTokens authenticates callers by bearer token, see TokenIdentity.
+func WithTokens(tokens map[string]string) AdminOption {
+	return WithIdentityFunc(TokenIdentity(tokens))
+}
+
+// PeerCertificateIdentity returns the common name, or else the first DNS name, of the
+// verified client certificate of an mTLS connection. Callers without a verified
+// certificate are anonymous and only get the "*" ACL entry.
+func PeerCertificateIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return certificateIdentity(info.State.VerifiedChains[0][0])
+}
+
+func certificateIdentity(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
+// TokenIdentity returns an identity function mapping the bearer token of the
+// authorization metadata to its identity in tokens. Callers without a known token fall
+// back to PeerCertificateIdentity.
+func TokenIdentity(tokens map[string]string) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			token, ok := strings.CutPrefix(value, "Bearer ")
+			if !ok || token == "" {
+				continue
+			}
+			for known, identity := range tokens {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(known)) == 1 {
+					return identity
+				}
+			}
+		}
+		return PeerCertificateIdentity(ctx)
+	}
+}
+
+// requestContext exposes the TLS state and Authorization header of an HTTP request the
+// way gRPC does, so one identity function authenticates both admin APIs.
+func requestContext(req *http.Request) context.Context {
+	ctx := req.Context()
+	if req.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *req.TLS}})
+	}
+	if auth := req.Header.Values("Authorization"); len(auth) > 0 {
+		md := metadata.MD{}
+		md.Append("authorization", auth...)
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
@@ -0,0 +1,141 @@
+package local
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"github.com/go-kratos/kratos/v2/registry"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestACLAllowed(t *testing.T) {
+	acl := ACL{
+		"orders-team": {"orders", "orders-*"},
+		"ops":         {"*"},
+		"*":           {"public-*"},
+	}
+	tests := []struct {
+		identity string
+		service  string
+		allowed  bool
+		all      bool
+	}{
+		{"orders-team", "orders", true, false},
+		{"orders-team", "orders-worker", true, false},
+		{"orders-team", "payments", false, false},
+		{"orders-team", "public-docs", true, false},
+		{"ops", "payments", true, true},
+		{"", "public-docs", true, false},
+		{"", "orders", false, false},
+		{"stranger", "orders", false, false},
+	}
+	for _, tt := range tests {
+		if got := acl.Allowed(tt.identity, tt.service); got != tt.allowed {
+			t.Errorf("Allowed(%q, %q) = %v, want %v", tt.identity, tt.service, got, tt.allowed)
+		}
+		if got := acl.AllowedAll(tt.identity); got != tt.all {
+			t.Errorf("AllowedAll(%q) = %v, want %v", tt.identity, got, tt.all)
+		}
+	}
+	if !ACL(nil).Allowed("", "orders") || !ACL(nil).AllowedAll("") {
+		t.Error("nil ACL must allow everything")
+	}
+}
+
+func verifiedContext(cn string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func TestIdentity(t *testing.T) {
+	claimed := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-registry-identity", "ops"))
+	unverified := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "ops"}}},
+	}}})
+	bearer := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	tokens := TokenIdentity(map[string]string{"s3cret": "ops"})
+	tests := []struct {
+		name     string
+		identity func(ctx context.Context) string
+		ctx      context.Context
+		want     string
+	}{
+		{"claimed metadata is ignored", PeerCertificateIdentity, claimed, ""},
+		{"unverified certificate", PeerCertificateIdentity, unverified, ""},
+		{"verified certificate", PeerCertificateIdentity, verifiedContext("orders-team"), "orders-team"},
+		{"known token", tokens, bearer("s3cret"), "ops"},
+		{"unknown token", tokens, bearer("guess"), ""},
+		{"token falls back to certificate", tokens, verifiedContext("orders-team"), "orders-team"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.identity(tt.ctx); got != tt.want {
+				t.Fatalf("identity %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminServerACL(t *testing.T) {
+	reg := New("test")
+	s := NewAdminServer(reg, WithACL(ACL{"orders-team": {"orders"}}))
+	req := &RegisterRequest{Instance: &Instance{Id: "orders-1", Name: "orders", Endpoints: []string{"grpc://10.0.0.1:9000"}}}
+	claimed := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-registry-identity", "orders-team"))
+	if _, err := s.Register(claimed, req); err == nil {
+		t.Fatal("claimed identity was trusted")
+	}
+	if _, err := s.Register(verifiedContext("orders-team"), req); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Deregister(verifiedContext("payments-team"), &DeregisterRequest{Id: "orders-1", Name: "orders"}); err == nil {
+		t.Fatal("foreign identity deregistered the instance")
+	}
+}
+
+func TestAdminHandlerACL(t *testing.T) {
+	reg := New("test")
+	err := reg.Register(context.Background(), &registry.ServiceInstance{ID: "orders-1", Name: "orders", Endpoints: []string{"grpc://10.0.0.1:9000"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewAdminHandler(reg,
+		WithACL(ACL{"orders-team": {"orders"}, "ops": {"*"}}),
+		WithTokens(map[string]string{"orders-token": "orders-team", "ops-token": "ops"}),
+	)
+	tests := []struct {
+		name   string
+		method string
+		target string
+		header http.Header
+		code   int
+	}{
+		{"anonymous deregister", http.MethodDelete, "/services/orders/instances/orders-1", nil, http.StatusForbidden},
+		{"claimed identity", http.MethodDelete, "/services/orders/instances/orders-1", http.Header{"X-Registry-Identity": {"ops"}}, http.StatusForbidden},
+		{"owner compacts", http.MethodPost, "/compact?max_age=1h", http.Header{"Authorization": {"Bearer orders-token"}}, http.StatusForbidden},
+		{"ops compacts", http.MethodPost, "/compact?max_age=1h", http.Header{"Authorization": {"Bearer ops-token"}}, http.StatusOK},
+		{"owner deregisters", http.MethodDelete, "/services/orders/instances/orders-1", http.Header{"Authorization": {"Bearer orders-token"}}, http.StatusNoContent},
+		{"reads stay open", http.MethodGet, "/services", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("status %d, want %d: %s", rec.Code, tt.code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package local
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/registry"
+	"io/fs"
+	"net/http"
+	"time"
+)
+
+//go:embed dashboard
+var dashboard embed.FS
+
+type adminService struct {
+	Name      string `json:"name"`
+	Endpoints int    `json:"endpoints"`
+}
+
+type adminError struct {
+	Error string `json:"error"`
+}
+
+// NewAdminHandler returns the REST admin API of reg:
+//
+//	GET    /services                         list service names
+//	GET    /services/{name}                  list the instances of a service
+//	DELETE /services/{name}/instances/{id}   force-deregister an instance
+//	GET    /snapshot                         download all entries as JSON
+//	POST   /compact?max_age=1h               prune entries not refreshed within max_age
+//	GET    /ui/                              embedded dashboard
+//
+// Deregistering and compacting are authorized like the gRPC admin server with the ACL
+// and identity function of opts; compacting requires a "*" grant.
+func NewAdminHandler(reg *Registry, opts ...AdminOption) http.Handler {
+	s := NewAdminServer(reg, opts...)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /services", func(w http.ResponseWriter, req *http.Request) {
+		entries := reg.entriesSnapshot()
+		services := make([]adminService, 0, len(entries))
+		for _, entry := range entries {
+			services = append(services, adminService{Name: entry.Name, Endpoints: len(entry.Endpoints)})
+		}
+		writeJSON(w, http.StatusOK, services)
+	})
+	mux.HandleFunc("GET /services/{name}", func(w http.ResponseWriter, req *http.Request) {
+		items, err := reg.GetService(req.Context(), req.PathValue("name"))
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, adminError{Error: err.Error()})
+			return
+		}
+		if len(items) == 0 {
+			writeJSON(w, http.StatusNotFound, adminError{Error: fmt.Sprintf("service %s not found", req.PathValue("name"))})
+			return
+		}
+		writeJSON(w, http.StatusOK, items)
+	})
+	mux.HandleFunc("DELETE /services/{name}/instances/{id}", func(w http.ResponseWriter, req *http.Request) {
+		service := &registry.ServiceInstance{ID: req.PathValue("id"), Name: req.PathValue("name")}
+		if err := s.authorize(requestContext(req), service.Name); err != nil {
+			writeJSON(w, http.StatusForbidden, adminError{Error: kerrors.FromError(err).GetMessage()})
+			return
+		}
+		if err := reg.Deregister(req.Context(), service); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrReadOnly) {
+				status = http.StatusForbidden
+			}
+			writeJSON(w, status, adminError{Error: err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /snapshot", func(w http.ResponseWriter, req *http.Request) {
+		snapshot := reg.snapshot()
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="registry-%s.json"`, snapshot.Created.Format("20060102150405")))
+		writeJSON(w, http.StatusOK, snapshot)
+	})
+	mux.HandleFunc("POST /compact", func(w http.ResponseWriter, req *http.Request) {
+		if err := s.authorizeAll(requestContext(req)); err != nil {
+			writeJSON(w, http.StatusForbidden, adminError{Error: kerrors.FromError(err).GetMessage()})
+			return
+		}
+		maxAge, err := time.ParseDuration(req.URL.Query().Get("max_age"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, adminError{Error: "invalid max_age: " + err.Error()})
+			return
+		}
+		pruned, err := reg.Compact(maxAge)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrReadOnly) {
+				status = http.StatusForbidden
+			}
+			writeJSON(w, status, adminError{Error: err.Error()})
+			return
+		}
+		if pruned == nil {
+			pruned = []*ServiceEntry{}
+		}
+		writeJSON(w, http.StatusOK, pruned)
+	})
+	ui, _ := fs.Sub(dashboard, "dashboard")
+	mux.Handle("GET /ui/", http.StripPrefix("/ui/", http.FileServerFS(ui)))
+	mux.Handle("GET /{$}", http.RedirectHandler("ui/", http.StatusFound))
+	return mux
+}
+
+// ServeAdmin serves the admin API of reg on addr. It blocks like http.ListenAndServe.
+func ServeAdmin(addr string, reg *Registry, opts ...AdminOption) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           NewAdminHandler(reg, opts...),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv.ListenAndServe()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,150 @@
+package local
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/registry"
+	"maps"
+	"time"
+)
+
+var (
+	_ RegistryAdminServer = (*AdminServer)(nil)
+)
+
+// AdminServer implements the RegistryAdmin gRPC service on top of a local registry,
+// so admin tooling can manage it over the network.
+type AdminServer struct {
+	UnimplementedRegistryAdminServer
+	reg      *Registry
+	acl      ACL
+	identity func(ctx context.Context) string
+}
+
+func NewAdminServer(reg *Registry, opts ...AdminOption) *AdminServer {
+	s := &AdminServer{
+		reg:      reg,
+		identity: PeerCertificateIdentity,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *AdminServer) ListServices(ctx context.Context, _ *ListServicesRequest) (*ListServicesReply, error) {
+	services, err := s.reg.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ListServicesReply{Services: services}, nil
+}
+
+func (s *AdminServer) GetService(_ context.Context, req *GetServiceRequest) (*GetServiceReply, error) {
+	reply := &GetServiceReply{}
+	for _, entry := range s.reg.entriesSnapshot() {
+		if entry.Name == req.GetName() {
+			reply.Instances = append(reply.Instances, newInstance(entry))
+		}
+	}
+	if len(reply.Instances) == 0 {
+		return nil, errors.NotFound("SERVICE_NOT_FOUND", "service "+req.GetName()+" not found")
+	}
+	return reply, nil
+}
+
+func (s *AdminServer) Register(ctx context.Context, req *RegisterRequest) (*RegisterReply, error) {
+	in := req.GetInstance()
+	if in.GetName() == "" || len(in.GetEndpoints()) == 0 {
+		return nil, errors.BadRequest("INVALID_INSTANCE", "instance name and endpoints are required")
+	}
+	if err := s.authorize(ctx, in.GetName()); err != nil {
+		return nil, err
+	}
+	// the status travels in the metadata, the revision is assigned by this registry
+	md := maps.Clone(in.GetMetadata())
+	status := Status(md[MetadataStatus])
+	delete(md, MetadataStatus)
+	delete(md, MetadataRevision)
+	switch status {
+	case "", StatusUp, StatusDown, StatusOutOfService:
+	default:
+		return nil, errors.BadRequest("INVALID_INSTANCE", fmt.Sprintf("unknown instance status %q", status))
+	}
+	err := s.reg.register(&registry.ServiceInstance{
+		ID:        in.GetId(),
+		Name:      in.GetName(),
+		Version:   in.GetVersion(),
+		Metadata:  md,
+		Endpoints: in.GetEndpoints(),
+	}, status)
+	if stderrors.Is(err, ErrReadOnly) {
+		return nil, errors.Forbidden("REGISTRY_READ_ONLY", err.Error())
+	}
+	if stderrors.Is(err, ErrInvalidInstance) {
+		return nil, errors.BadRequest("INVALID_INSTANCE", err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RegisterReply{}, nil
+}
+
+func (s *AdminServer) Deregister(ctx context.Context, req *DeregisterRequest) (*DeregisterReply, error) {
+	if err := s.authorize(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	err := s.reg.Deregister(ctx, &registry.ServiceInstance{ID: req.GetId(), Name: req.GetName()})
+	if stderrors.Is(err, ErrReadOnly) {
+		return nil, errors.Forbidden("REGISTRY_READ_ONLY", err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &DeregisterReply{}, nil
+}
+
+func (s *AdminServer) Snapshot(_ context.Context, _ *SnapshotRequest) (*SnapshotReply, error) {
+	reply := &SnapshotReply{
+		Authority: s.reg.authority,
+		Created:   time.Now().Unix(),
+		Revision:  s.reg.Revision(),
+	}
+	for _, entry := range s.reg.entriesSnapshot() {
+		reply.Instances = append(reply.Instances, newInstance(entry))
+	}
+	return reply, nil
+}
+
+func (s *AdminServer) authorize(ctx context.Context, service string) error {
+	identity := s.identity(ctx)
+	if !s.acl.Allowed(identity, service) {
+		return errors.Forbidden("ACCESS_DENIED", fmt.Sprintf("identity %q may not modify service %s", identity, service))
+	}
+	return nil
+}
+
+func (s *AdminServer) authorizeAll(ctx context.Context) error {
+	identity := s.identity(ctx)
+	if !s.acl.AllowedAll(identity) {
+		return errors.Forbidden("ACCESS_DENIED", fmt.Sprintf("identity %q may not modify every service", identity))
+	}
+	return nil
+}
+
+func newInstance(entry *ServiceEntry) *Instance {
+	instance := &Instance{
+		Id:        entry.ID,
+		Name:      entry.Name,
+		Version:   entry.Version,
+		Metadata:  entry.metadata(),
+		Endpoints: entry.Endpoints,
+		Revision:  entry.Revision,
+	}
+	if !entry.Timestamp.IsZero() {
+		instance.Timestamp = entry.Timestamp.Unix()
+	}
+	return instance
+}
@@ -0,0 +1,62 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+	"sync"
+	"time"
+)
+
+var (
+	_ transport.Server = (*Deregisterer)(nil)
+)
+
+const deregisterTimeout = 5 * time.Second
+
+// Deregisterer deregisters an instance when the kratos app it is registered with stops.
+type Deregisterer struct {
+	reg      registry.Registrar
+	instance *registry.ServiceInstance
+	stop     chan struct{}
+	once     *sync.Once
+}
+
+// AutoDeregister deregisters instance from reg when the app stops, for instances
+// registered outside the app's own registrar:
+//
+//	app := kratos.New(kratos.Server(srv, local.AutoDeregister(reg, instance)))
+//
+// The app handles SIGINT and SIGTERM, so graceful shutdown and deferred cleanup still
+// run. Without a kratos app, defer the Stop method in main.
+func AutoDeregister(reg registry.Registrar, instance *registry.ServiceInstance) *Deregisterer {
+	return &Deregisterer{
+		reg:      reg,
+		instance: instance,
+		stop:     make(chan struct{}),
+		once:     &sync.Once{},
+	}
+}
+
+func (d *Deregisterer) Start(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+	case <-d.stop:
+	}
+	return nil
+}
+
+// Stop deregisters the instance once, within the deadline of ctx or five seconds.
+func (d *Deregisterer) Stop(ctx context.Context) error {
+	var err error
+	d.once.Do(func() {
+		close(d.stop)
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deregisterTimeout)
+			defer cancel()
+		}
+		err = d.reg.Deregister(ctx, d.instance)
+	})
+	return err
+}
@@ -0,0 +1,38 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/registry"
+	"testing"
+	"time"
+)
+
+func TestAutoDeregister(t *testing.T) {
+	reg := New("test")
+	instance := &registry.ServiceInstance{ID: "orders-1", Name: "orders", Endpoints: []string{"grpc://10.0.0.1:9000"}}
+	if err := reg.Register(context.Background(), instance); err != nil {
+		t.Fatal(err)
+	}
+	d := AutoDeregister(reg, instance)
+	started := make(chan error, 1)
+	go func() { started <- d.Start(context.Background()) }()
+
+	if err := d.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-started:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+	items, err := reg.GetService(context.Background(), "orders")
+	if err != nil || len(items) != 0 {
+		t.Fatalf("instance still registered: %d, err %v", len(items), err)
+	}
+	if err := d.Stop(context.Background()); err != nil {
+		t.Fatalf("second Stop: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/registry"
+)
+
+type EventType string
+
+const (
+	EventAdded    EventType = "ADDED"
+	EventModified EventType = "MODIFIED"
+	EventDeleted  EventType = "DELETED"
+)
+
+// Event describes a change of one instance. Deleted events carry the last known instance.
+type Event struct {
+	Type     EventType
+	Instance *registry.ServiceInstance
+}
+
+// EventWatcher yields typed change events instead of full instance lists.
+type EventWatcher struct {
+	w    *watcher
+	prev map[string]*registry.ServiceInstance
+}
+
+// WatchEvents watches name and reports the changes between successive instance lists.
+// The first batch holds an ADDED event for every current instance.
+func (r *Registry) WatchEvents(ctx context.Context, name string) (*EventWatcher, error) {
+	if r.isClosed() {
+		return nil, ErrRegistryClosed
+	}
+	w, err := newWatcher(ctx, r, name, r.opts.pollInterval)
+	if err != nil {
+		return nil, err
+	}
+	return &EventWatcher{w: w, prev: map[string]*registry.ServiceInstance{}}, nil
+}
+
+// Next blocks until at least one instance changed and returns the events.
+func (w *EventWatcher) Next() ([]*Event, error) {
+	return w.NextWithContext(context.Background())
+}
+
+func (w *EventWatcher) NextWithContext(ctx context.Context) ([]*Event, error) {
+	for {
+		items, err := w.w.NextWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if events := w.diff(items); len(events) > 0 {
+			return events, nil
+		}
+	}
+}
+
+func (w *EventWatcher) Stop() error {
+	return w.w.Stop()
+}
+
+func (w *EventWatcher) diff(items []*registry.ServiceInstance) []*Event {
+	var events []*Event
+	next := make(map[string]*registry.ServiceInstance, len(items))
+	for _, item := range items {
+		next[item.ID] = item
+		prev, ok := w.prev[item.ID]
+		switch {
+		case !ok:
+			events = append(events, &Event{Type: EventAdded, Instance: item})
+		case !equalInstance(prev, item):
+			events = append(events, &Event{Type: EventModified, Instance: item})
+		}
+	}
+	for id, prev := range w.prev {
+		if _, ok := next[id]; !ok {
+			events = append(events, &Event{Type: EventDeleted, Instance: prev})
+		}
+	}
+	w.prev = next
+	return events
+}
@@ -0,0 +1,53 @@
+package local
+
+import (
+	"net"
+	"os"
+	"strings"
+)
+
+// ExpandEndpoint resolves ${VAR} placeholders in endpoint: HOST_IP is the primary
+// non-loopback IPv4 address, HOSTNAME the host name, anything else an environment variable.
+// An environment variable of the same name overrides HOST_IP and HOSTNAME.
+func ExpandEndpoint(endpoint string) string {
+	if !strings.Contains(endpoint, "${") {
+		return endpoint
+	}
+	return os.Expand(endpoint, func(key string) string {
+		if v, ok := os.LookupEnv(key); ok {
+			return v
+		}
+		switch key {
+		case "HOST_IP":
+			return hostIP()
+		case "HOSTNAME":
+			name, _ := os.Hostname()
+			return name
+		default:
+			return ""
+		}
+	})
+}
+
+func expandEndpoints(endpoints []string) []string {
+	expanded := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		expanded = append(expanded, ExpandEndpoint(endpoint))
+	}
+	return expanded
+}
+
+func hostIP() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ""
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() && !ipnet.IP.IsLinkLocalUnicast() {
+			if ip4 := ipnet.IP.To4(); ip4 != nil {
+				return ip4.String()
+			}
+		}
+	}
+	return ""
+}
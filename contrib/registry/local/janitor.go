@@ -0,0 +1,78 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/transport"
+	"sync"
+	"time"
+)
+
+var (
+	_ transport.Server = (*Janitor)(nil)
+)
+
+const (
+	defaultJanitorInterval = time.Minute
+)
+
+// Janitor periodically compacts a registry, removing entries older than maxAge.
+// onPrune, if set, is called for every removed entry.
+type Janitor struct {
+	reg      *Registry
+	interval time.Duration
+	maxAge   time.Duration
+	onPrune  func(entry *ServiceEntry)
+	stop     chan struct{}
+	once     *sync.Once
+}
+
+// NewJanitor compacts reg every interval, every minute when interval isn't positive.
+func NewJanitor(reg *Registry, interval, maxAge time.Duration, onPrune func(entry *ServiceEntry)) *Janitor {
+	if interval <= 0 {
+		interval = defaultJanitorInterval
+	}
+	return &Janitor{
+		reg:      reg,
+		interval: interval,
+		maxAge:   maxAge,
+		onPrune:  onPrune,
+		stop:     make(chan struct{}),
+		once:     &sync.Once{},
+	}
+}
+
+func (j *Janitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-j.stop:
+			return nil
+		case <-ticker.C:
+			j.compact()
+		}
+	}
+}
+
+func (j *Janitor) Stop(_ context.Context) error {
+	j.once.Do(func() {
+		close(j.stop)
+	})
+	return nil
+}
+
+func (j *Janitor) compact() {
+	pruned, err := j.reg.Compact(j.maxAge)
+	if err != nil {
+		j.reg.log.Errorf("compact local registry error -> %s", err.Error())
+		return
+	}
+	for _, entry := range pruned {
+		j.reg.log.Infof("local registry pruned stale entry <%s> of service %s, last seen at %s", entry.ID, entry.Name, entry.Timestamp.Format(time.RFC3339))
+		if j.onPrune != nil {
+			j.onPrune(entry)
+		}
+	}
+}
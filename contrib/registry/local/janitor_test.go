@@ -0,0 +1,22 @@
+package local
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJanitorDefaultsInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		j := NewJanitor(New("test"), interval, time.Minute, nil)
+		if j.interval != defaultJanitorInterval {
+			t.Fatalf("interval %v: janitor runs every %v", interval, j.interval)
+		}
+		done := make(chan error, 1)
+		go func() { done <- j.Start(context.Background()) }()
+		_ = j.Stop(context.Background())
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package local
+
+import (
+	"sync"
+)
+
+// notifier wakes every waiter when the registry changes, so watchers don't have to wait
+// for the next poll tick.
+type notifier struct {
+	ch chan struct{}
+	m  *sync.Mutex
+}
+
+func newNotifier() *notifier {
+	return &notifier{
+		ch: make(chan struct{}),
+		m:  &sync.Mutex{},
+	}
+}
+
+// wait returns a channel that is closed on the next change.
+func (n *notifier) wait() <-chan struct{} {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return n.ch
+}
+
+func (n *notifier) broadcast() {
+	n.m.Lock()
+	defer n.m.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
@@ -0,0 +1,104 @@
+package local
+
+import (
+	"github.com/go-kratos/kratos/v2/log"
+	"time"
+)
+
+const (
+	defaultPollInterval = time.Second
+)
+
+type Option func(o *options)
+
+type options struct {
+	entries      []*ServiceEntry
+	pollInterval time.Duration
+	ttl          time.Duration
+	logger       log.Logger
+	readOnly     bool
+	healthyOnly  bool
+	namespace    string
+	validators   []Validator
+	ordering     Ordering
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		pollInterval: defaultPollInterval,
+		logger:       log.DefaultLogger,
+		validators:   DefaultValidators,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithEntries seeds the registry with static entries, which never expire.
+func WithEntries(entries ...*ServiceEntry) Option {
+	return func(o *options) {
+		o.entries = append(o.entries, entries...)
+	}
+}
+
+// WithPollInterval sets how often a watcher re-checks the registry. Writes wake watchers
+// immediately, polling only catches TTL expiry.
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval > 0 {
+			o.pollInterval = interval
+		}
+	}
+}
+
+// WithTTL expires registered entries that were not re-registered within ttl. Zero disables expiry.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+	}
+}
+
+// WithLogger sets the logger expired entries are reported to, log.DefaultLogger by default.
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithReadOnly rejects Register and Deregister calls.
+func WithReadOnly(readOnly bool) Option {
+	return func(o *options) {
+		o.readOnly = readOnly
+	}
+}
+
+// WithHealthyOnly makes GetService and watchers skip instances whose status is not UP.
+func WithHealthyOnly(healthyOnly bool) Option {
+	return func(o *options) {
+		o.healthyOnly = healthyOnly
+	}
+}
+
+// WithNamespace isolates the instances of the registry from other namespaces, e.g. dev and staging.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithValidators replaces the validators run on Register, DefaultValidators by default.
+func WithValidators(validators ...Validator) Option {
+	return func(o *options) {
+		o.validators = validators
+	}
+}
+
+// WithOrdering sets the order of the endpoints returned by GetService, OrderNone by default.
+func WithOrdering(ordering Ordering) Option {
+	return func(o *options) {
+		o.ordering = ordering
+	}
+}
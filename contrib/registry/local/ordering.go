@@ -0,0 +1,42 @@
+package local
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+// Ordering decides the order of the endpoints returned by GetService, for clients that
+// don't go through a kratos balancer and would otherwise all pick the first endpoint.
+type Ordering int
+
+const (
+	// OrderNone keeps registration order.
+	OrderNone Ordering = iota
+	// OrderShuffle returns the endpoints in random order.
+	OrderShuffle
+	// OrderRoundRobin rotates the endpoints on every call, so the least recently returned comes first.
+	OrderRoundRobin
+)
+
+type orderer struct {
+	ordering Ordering
+	cursors  *sync.Map
+}
+
+func (o *orderer) order(key string, endpoints []string) {
+	if len(endpoints) < 2 {
+		return
+	}
+	switch o.ordering {
+	case OrderShuffle:
+		rand.Shuffle(len(endpoints), func(i, j int) {
+			endpoints[i], endpoints[j] = endpoints[j], endpoints[i]
+		})
+	case OrderRoundRobin:
+		v, _ := o.cursors.LoadOrStore(key, &atomic.Uint64{})
+		n := int((v.(*atomic.Uint64).Add(1) - 1) % uint64(len(endpoints)))
+		rotated := append(endpoints[n:len(endpoints):len(endpoints)], endpoints[:n]...)
+		copy(endpoints, rotated)
+	}
+}
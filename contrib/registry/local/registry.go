@@ -2,11 +2,18 @@ package local
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/registry"
+	"maps"
+	"path"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -14,11 +21,38 @@ var (
 	_ registry.Discovery = (*Registry)(nil)
 )
 
+var (
+	ErrReadOnly         = errors.New("local registry is read-only")
+	ErrInstanceNotFound = errors.New("local registry instance not found")
+	ErrRegistryClosed   = errors.New("local registry is closed")
+	ErrInvalidInstance  = errors.New("invalid service instance")
+)
+
+const (
+	// MetadataRevision is the metadata key carrying the revision of the last write to an instance.
+	MetadataRevision = "revision"
+	// MetadataStatus is the metadata key carrying the health status of an instance.
+	MetadataStatus = "status"
+)
+
+type Status string
+
+const (
+	StatusUp           Status = "UP"
+	StatusDown         Status = "DOWN"
+	StatusOutOfService Status = "OUT_OF_SERVICE"
+)
+
 type ServiceEntry struct {
-	ID        string
-	Name      string
-	Endpoints []string
-	Version   string
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Endpoints []string          `json:"endpoints"`
+	Version   string            `json:"version"`
+	Timestamp time.Time         `json:"timestamp"`
+	Revision  uint64            `json:"revision"`
+	Status    Status            `json:"status"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
 }
 
 func NewServiceEntry(id, name, version string, endpoints ...string) *ServiceEntry {
@@ -30,78 +64,373 @@ func NewServiceEntry(id, name, version string, endpoints ...string) *ServiceEntr
 		Name:      name,
 		Endpoints: endpoints,
 		Version:   version,
+		Status:    StatusUp,
+	}
+}
+
+// metadata returns a copy of the entry metadata with the revision and status keys set.
+func (e *ServiceEntry) metadata() map[string]string {
+	md := make(map[string]string, len(e.Metadata)+2)
+	maps.Copy(md, e.Metadata)
+	md[MetadataRevision] = strconv.FormatUint(e.Revision, 10)
+	md[MetadataStatus] = string(e.status())
+	return md
+}
+
+func (e *ServiceEntry) status() Status {
+	if e.Status == "" {
+		return StatusUp
 	}
+	return e.Status
+}
+
+func (e *ServiceEntry) healthy() bool {
+	return e.status() == StatusUp
 }
 
 type Registry struct {
 	authority string
+	namespace string
+	opts      *options
 	entries   map[string]*ServiceEntry
-	m         *sync.Mutex
+	revision  *atomic.Uint64
+	changes   *notifier
+	orderer   *orderer
+	closed    chan struct{}
+	closeOnce *sync.Once
+	log       *log.Helper
+	m         *sync.RWMutex
 }
 
-func New(authority string, entries ...*ServiceEntry) *Registry {
+func New(authority string, opts ...Option) *Registry {
+	o := newOptions(opts...)
 	r := &Registry{
 		authority: authority,
+		namespace: o.namespace,
+		opts:      o,
 		entries:   map[string]*ServiceEntry{},
-		m:         &sync.Mutex{},
+		revision:  &atomic.Uint64{},
+		changes:   newNotifier(),
+		orderer:   &orderer{ordering: o.ordering, cursors: &sync.Map{}},
+		closed:    make(chan struct{}),
+		closeOnce: &sync.Once{},
+		log:       log.NewHelper(o.logger),
+		m:         &sync.RWMutex{},
 	}
-	for i := range entries {
-		key := normalizeName(r.authority, entries[i].Name)
-		r.entries[key] = entries[i]
+	for i := range o.entries {
+		o.entries[i].Endpoints = expandEndpoints(o.entries[i].Endpoints)
+		o.entries[i].Namespace = r.namespace
+		o.entries[i].Revision = r.nextRevision()
+		r.entries[r.key(o.entries[i].Name)] = o.entries[i]
 	}
 	return r
 }
 
+// Namespace returns a view of the registry scoped to namespace. Views share storage and
+// revisions, but instances registered in one namespace are invisible to the others.
+func (r *Registry) Namespace(namespace string) *Registry {
+	view := *r
+	view.namespace = namespace
+	return &view
+}
+
 func (r *Registry) Register(_ context.Context, service *registry.ServiceInstance) error {
+	return r.register(service, "")
+}
+
+// register stores service with status, or keeps the status of a known instance when
+// status is empty.
+func (r *Registry) register(service *registry.ServiceInstance, status Status) error {
+	if r.isClosed() {
+		return ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		return ErrReadOnly
+	}
+	if err := r.validate(service); err != nil {
+		return err
+	}
+	endpoints := expandEndpoints(service.Endpoints)
 	r.m.Lock()
 	defer r.m.Unlock()
-	key := normalizeName(r.authority, service.Name)
+	key := r.key(service.Name)
 	if entry, ok := r.entries[key]; ok {
-		for _, endpoint := range service.Endpoints {
+		for _, endpoint := range endpoints {
 			if !slices.Contains(entry.Endpoints, endpoint) {
 				entry.Endpoints = append(entry.Endpoints, endpoint)
 			}
 		}
+		if service.Metadata != nil {
+			entry.Namespace = r.namespace
+			entry.Metadata = maps.Clone(service.Metadata)
+		}
+		if !entry.Timestamp.IsZero() {
+			entry.Timestamp = time.Now()
+		}
+		if status != "" {
+			entry.Status = status
+		}
+		entry.Revision = r.nextRevision()
 		return nil
 	}
 
-	entry := NewServiceEntry(service.ID, service.Name, service.Version, service.Endpoints...)
+	entry := NewServiceEntry(service.ID, service.Name, service.Version, endpoints...)
+	entry.Namespace = r.namespace
+	entry.Metadata = maps.Clone(service.Metadata)
+	entry.Timestamp = time.Now()
+	if status != "" {
+		entry.Status = status
+	}
+	entry.Revision = r.nextRevision()
 	r.entries[key] = entry
 	return nil
 }
 
 func (r *Registry) Deregister(_ context.Context, service *registry.ServiceInstance) error {
+	if r.isClosed() {
+		return ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		return ErrReadOnly
+	}
 	r.m.Lock()
 	defer r.m.Unlock()
-	key := normalizeName(r.authority, service.Name)
+	key := r.key(service.Name)
 	if entry, ok := r.entries[key]; ok {
 		if entry.Name == service.Name && entry.ID == service.ID {
 			delete(r.entries, key)
+			r.nextRevision()
 		}
 	}
 	return nil
 }
 
 func (r *Registry) GetService(_ context.Context, name string) ([]*registry.ServiceInstance, error) {
-	r.m.Lock()
-	defer r.m.Unlock()
+	if r.isClosed() {
+		return nil, ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		r.m.RLock()
+		defer r.m.RUnlock()
+	} else {
+		r.m.Lock()
+		defer r.m.Unlock()
+	}
 	items := make([]*registry.ServiceInstance, 0)
-	key := normalizeName(r.authority, name)
+	key := r.key(name)
 	if entry, ok := r.entries[key]; ok {
+		if r.expired(entry) {
+			// a read-only registry never writes, expired entries are only hidden
+			if !r.opts.readOnly {
+				r.log.Infof("local registry entry <%s> expired, last seen at %s", entry.ID, entry.Timestamp.Format(time.RFC3339))
+				delete(r.entries, key)
+				r.nextRevision()
+			}
+			return items, nil
+		}
+		if r.opts.healthyOnly && !entry.healthy() {
+			return items, nil
+		}
 		item := &registry.ServiceInstance{
 			ID:        entry.ID,
 			Name:      entry.Name,
 			Version:   entry.Version,
-			Metadata:  make(map[string]string),
-			Endpoints: entry.Endpoints,
+			Metadata:  entry.metadata(),
+			Endpoints: slices.Clone(entry.Endpoints),
 		}
+		r.orderer.order(key, item.Endpoints)
 		items = append(items, item)
 	}
 	return items, nil
 }
 
-func (r *Registry) Watch(_ context.Context, name string) (registry.Watcher, error) {
-	return newWatcher(name)
+// SetHealth changes the status of the instance with serviceID, so it can leave rotation
+// without deregistering.
+func (r *Registry) SetHealth(_ context.Context, serviceID string, status Status) error {
+	if r.isClosed() {
+		return ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		return ErrReadOnly
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for _, entry := range r.entries {
+		if entry.ID == serviceID && entry.Namespace == r.namespace && !r.expired(entry) {
+			if entry.status() != status {
+				entry.Status = status
+				entry.Revision = r.nextRevision()
+			}
+			return nil
+		}
+	}
+	return ErrInstanceNotFound
+}
+
+// ListServices returns the sorted names of all live services.
+func (r *Registry) ListServices(_ context.Context) ([]string, error) {
+	if r.isClosed() {
+		return nil, ErrRegistryClosed
+	}
+	var names []string
+	for _, entry := range r.entriesSnapshot() {
+		if !slices.Contains(names, entry.Name) {
+			names = append(names, entry.Name)
+		}
+	}
+	return names, nil
+}
+
+// GetServices returns the instances of every service whose name matches pattern, keyed by
+// service name. The pattern uses path.Match syntax, e.g. "billing.*"; a trailing "*" also
+// matches names containing "/".
+func (r *Registry) GetServices(ctx context.Context, pattern string) (map[string][]*registry.ServiceInstance, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	services := make(map[string][]*registry.ServiceInstance)
+	for _, entry := range r.entriesSnapshot() {
+		if !matchName(pattern, entry.Name) {
+			continue
+		}
+		items, err := r.GetService(ctx, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) > 0 {
+			services[entry.Name] = items
+		}
+	}
+	return services, nil
+}
+
+// ContextWatcher is a registry.Watcher whose Next can be bounded by a caller context.
+type ContextWatcher interface {
+	registry.Watcher
+	NextWithContext(ctx context.Context) ([]*registry.ServiceInstance, error)
+}
+
+// Watch returns a ContextWatcher that stops once ctx is done. An empty name or "*" watches
+// every service, Next then returns the instances of all of them; see WatchServices for a map.
+func (r *Registry) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	if r.isClosed() {
+		return nil, ErrRegistryClosed
+	}
+	return newWatcher(ctx, r, name, r.opts.pollInterval)
+}
+
+// Compact removes the registered entries not refreshed within maxAge, even when no TTL is set,
+// and returns them. Seeded entries are kept.
+func (r *Registry) Compact(maxAge time.Duration) ([]*ServiceEntry, error) {
+	if r.isClosed() {
+		return nil, ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		return nil, ErrReadOnly
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	var pruned []*ServiceEntry
+	for key, entry := range r.entries {
+		if entry.Namespace != r.namespace || entry.Timestamp.IsZero() || time.Since(entry.Timestamp) <= maxAge {
+			continue
+		}
+		delete(r.entries, key)
+		pruned = append(pruned, entry)
+	}
+	if len(pruned) > 0 {
+		r.nextRevision()
+	}
+	return pruned, nil
+}
+
+// Close stops every watcher of the registry and its namespace views. Later calls
+// return ErrRegistryClosed.
+func (r *Registry) Close(_ context.Context) error {
+	r.closeOnce.Do(func() {
+		close(r.closed)
+	})
+	return nil
+}
+
+func (r *Registry) isClosed() bool {
+	select {
+	case <-r.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// replace swaps every entry of the namespace for entries, bypassing read-only mode since
+// it is only used to mirror another registry.
+func (r *Registry) replace(entries []*ServiceEntry) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	for key, entry := range r.entries {
+		if entry.Namespace == r.namespace {
+			delete(r.entries, key)
+		}
+	}
+	revision := r.nextRevision()
+	for _, entry := range entries {
+		entry.Namespace = r.namespace
+		entry.Revision = revision
+		r.entries[r.key(entry.Name)] = entry
+	}
+}
+
+// Revision returns the current revision of the registry. It is incremented on every write,
+// so consumers can detect missed updates and resume a sync from a known point.
+func (r *Registry) Revision() uint64 {
+	return r.revision.Load()
+}
+
+func (r *Registry) nextRevision() uint64 {
+	defer r.changes.broadcast()
+	return r.revision.Add(1)
+}
+
+// entriesSnapshot returns copies of all live entries sorted by name.
+func (r *Registry) entriesSnapshot() []*ServiceEntry {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	entries := make([]*ServiceEntry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.Namespace != r.namespace || r.expired(entry) {
+			continue
+		}
+		cp := *entry
+		cp.Endpoints = slices.Clone(entry.Endpoints)
+		cp.Metadata = maps.Clone(entry.Metadata)
+		entries = append(entries, &cp)
+	}
+	slices.SortFunc(entries, func(a, b *ServiceEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+func (r *Registry) expired(entry *ServiceEntry) bool {
+	if r.opts.ttl <= 0 || entry.Timestamp.IsZero() {
+		return false
+	}
+	return time.Since(entry.Timestamp) > r.opts.ttl
+}
+
+func matchName(pattern, name string) bool {
+	if ok, _ := path.Match(pattern, name); ok {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	return ok && !strings.ContainsAny(prefix, "*?[\\") && strings.HasPrefix(name, prefix)
+}
+
+func (r *Registry) key(name string) string {
+	if r.namespace == "" {
+		return normalizeName(r.authority, name)
+	}
+	return r.namespace + "/" + normalizeName(r.authority, name)
 }
 
 func normalizeName(authority, name string) string {
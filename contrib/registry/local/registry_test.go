@@ -0,0 +1,226 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kratos/kratos/v2/registry"
+	"slices"
+	"testing"
+	"time"
+)
+
+func instance(id, name string, endpoints ...string) *registry.ServiceInstance {
+	return &registry.ServiceInstance{ID: id, Name: name, Version: "v1", Endpoints: endpoints}
+}
+
+func endpointsOf(t *testing.T, r *Registry, name string) []string {
+	t.Helper()
+	items, err := r.GetService(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var endpoints []string
+	for _, item := range items {
+		endpoints = append(endpoints, item.Endpoints...)
+	}
+	slices.Sort(endpoints)
+	return endpoints
+}
+
+func TestRegisterDeregister(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		steps func(ctx context.Context, r *Registry) error
+		want  []string
+		err   error
+	}{
+		{
+			name: "register",
+			steps: func(ctx context.Context, r *Registry) error {
+				return r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000"))
+			},
+			want: []string{"grpc://10.0.0.1:9000"},
+		},
+		{
+			name: "register merges endpoints",
+			steps: func(ctx context.Context, r *Registry) error {
+				if err := r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000")); err != nil {
+					return err
+				}
+				return r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000", "http://10.0.0.1:8000"))
+			},
+			want: []string{"grpc://10.0.0.1:9000", "http://10.0.0.1:8000"},
+		},
+		{
+			name: "invalid instance",
+			steps: func(ctx context.Context, r *Registry) error {
+				return r.Register(ctx, instance("a", "orders"))
+			},
+			err: ErrInvalidInstance,
+		},
+		{
+			name: "deregister",
+			steps: func(ctx context.Context, r *Registry) error {
+				if err := r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000")); err != nil {
+					return err
+				}
+				return r.Deregister(ctx, instance("a", "orders"))
+			},
+		},
+		{
+			name: "deregister keeps other ids",
+			steps: func(ctx context.Context, r *Registry) error {
+				if err := r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000")); err != nil {
+					return err
+				}
+				return r.Deregister(ctx, instance("b", "orders"))
+			},
+			want: []string{"grpc://10.0.0.1:9000"},
+		},
+		{
+			name: "read-only",
+			opts: []Option{WithReadOnly(true), WithEntries(NewServiceEntry("a", "orders", "v1", "grpc://10.0.0.1:9000"))},
+			steps: func(ctx context.Context, r *Registry) error {
+				return r.Deregister(ctx, instance("a", "orders"))
+			},
+			want: []string{"grpc://10.0.0.1:9000"},
+			err:  ErrReadOnly,
+		},
+		{
+			name: "closed",
+			steps: func(ctx context.Context, r *Registry) error {
+				_ = r.Close(ctx)
+				return r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000"))
+			},
+			err: ErrRegistryClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := New("test", tt.opts...)
+			if err := tt.steps(ctx, r); !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if errors.Is(tt.err, ErrRegistryClosed) {
+				return
+			}
+			if got := endpointsOf(t, r, "orders"); !slices.Equal(got, tt.want) {
+				t.Fatalf("endpoints %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	ctx := context.Background()
+	r := New("test", WithPollInterval(time.Hour))
+	w, err := r.Watch(ctx, "orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	if items, err := w.Next(); err != nil || len(items) != 0 {
+		t.Fatalf("first Next = %v, %v, want no instances", items, err)
+	}
+
+	go func() {
+		_ = r.Register(ctx, instance("b", "payments", "grpc://10.0.0.2:9000"))
+		_ = r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000"))
+	}()
+	items, err := w.Next()
+	if err != nil || len(items) != 1 || items[0].ID != "a" {
+		t.Fatalf("Next after register = %v, %v", items, err)
+	}
+
+	go func() {
+		_ = r.Deregister(ctx, instance("a", "orders"))
+	}()
+	if items, err := w.Next(); err != nil || len(items) != 0 {
+		t.Fatalf("Next after deregister = %v, %v", items, err)
+	}
+
+	bounded, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := w.(ContextWatcher).NextWithContext(bounded); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("NextWithContext without changes = %v, want deadline exceeded", err)
+	}
+
+	_ = r.Close(ctx)
+	if _, err := w.Next(); !errors.Is(err, ErrRegistryClosed) {
+		t.Fatalf("Next after close = %v, want %v", err, ErrRegistryClosed)
+	}
+}
+
+func TestWatchServices(t *testing.T) {
+	ctx := context.Background()
+	r := New("test", WithPollInterval(time.Hour))
+	w, err := r.WatchServices(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	if services, err := w.Next(); err != nil || len(services) != 0 {
+		t.Fatalf("first Next = %v, %v, want no services", services, err)
+	}
+	go func() {
+		_ = r.Register(ctx, instance("a", "orders", "grpc://10.0.0.1:9000"))
+	}()
+	services, err := w.Next()
+	if err != nil || len(services["orders"]) != 1 {
+		t.Fatalf("Next after register = %v, %v", services, err)
+	}
+	_ = w.Stop()
+	if _, err := w.Next(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Next after stop = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+	r := New("test", WithEntries(NewServiceEntry("seeded", "config", "v1", "grpc://10.0.0.9:9000")))
+	other := r.Namespace("staging")
+	for _, reg := range []*Registry{r, other} {
+		if err := reg.Register(ctx, instance("old", "orders", "grpc://10.0.0.1:9000")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Register(ctx, instance("new", "payments", "grpc://10.0.0.2:9000")); err != nil {
+		t.Fatal(err)
+	}
+	r.m.Lock()
+	for _, entry := range r.entries {
+		if entry.ID == "old" {
+			entry.Timestamp = time.Now().Add(-2 * time.Hour)
+		}
+	}
+	r.m.Unlock()
+
+	revision := r.Revision()
+	pruned, err := r.Compact(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pruned) != 1 || pruned[0].ID != "old" || pruned[0].Namespace != "" {
+		t.Fatalf("pruned %v, want the old entry of the default namespace", pruned)
+	}
+	if r.Revision() == revision {
+		t.Fatal("compaction did not bump the revision")
+	}
+	names, _ := r.ListServices(ctx)
+	if want := []string{"config", "payments"}; !slices.Equal(names, want) {
+		t.Fatalf("services %v, want %v", names, want)
+	}
+	if got := endpointsOf(t, other, "orders"); len(got) != 1 {
+		t.Fatalf("compaction pruned another namespace: %v", got)
+	}
+
+	revision = r.Revision()
+	if pruned, _ := r.Compact(time.Hour); len(pruned) != 0 || r.Revision() != revision {
+		t.Fatalf("second compaction pruned %v", pruned)
+	}
+	if _, err := New("test", WithReadOnly(true)).Compact(time.Hour); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("read-only compaction = %v, want %v", err, ErrReadOnly)
+	}
+}
@@ -0,0 +1,133 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/registry"
+	"google.golang.org/grpc"
+	"strconv"
+)
+
+var (
+	_ RegistryWatchServer = (*WatchServer)(nil)
+	_ registry.Discovery  = (*RemoteDiscovery)(nil)
+	_ registry.Watcher    = (*remoteWatcher)(nil)
+)
+
+// WatchServer streams the changes of a local registry to remote watchers. It is opt-in:
+// register it on the gRPC server of the process that owns the registry.
+type WatchServer struct {
+	UnimplementedRegistryWatchServer
+	reg *Registry
+}
+
+func NewWatchServer(reg *Registry) *WatchServer {
+	return &WatchServer{reg: reg}
+}
+
+func (s *WatchServer) Watch(req *WatchRequest, stream RegistryWatch_WatchServer) error {
+	w, err := s.reg.Watch(stream.Context(), req.GetName())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = w.Stop()
+	}()
+	for {
+		items, err := w.Next()
+		if err != nil {
+			if stream.Context().Err() != nil {
+				return nil
+			}
+			return err
+		}
+		reply := &WatchReply{Revision: s.reg.Revision()}
+		for _, item := range items {
+			reply.Instances = append(reply.Instances, newInstanceFromService(item))
+		}
+		if err = stream.Send(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// RemoteDiscovery is a registry.Discovery backed by the admin and watch services of a
+// remote process, so clients don't have to reach the registry owner's storage.
+type RemoteDiscovery struct {
+	admin RegistryAdminClient
+	watch RegistryWatchClient
+}
+
+func NewRemoteDiscovery(conn grpc.ClientConnInterface) *RemoteDiscovery {
+	return &RemoteDiscovery{
+		admin: NewRegistryAdminClient(conn),
+		watch: NewRegistryWatchClient(conn),
+	}
+}
+
+func (d *RemoteDiscovery) GetService(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	reply, err := d.admin.GetService(ctx, &GetServiceRequest{Name: name})
+	if errors.IsNotFound(err) {
+		return make([]*registry.ServiceInstance, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toServiceInstances(reply.GetInstances()), nil
+}
+
+func (d *RemoteDiscovery) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := d.watch.Watch(ctx, &WatchRequest{Name: name})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &remoteWatcher{stream: stream, cancel: cancel}, nil
+}
+
+type remoteWatcher struct {
+	stream RegistryWatch_WatchClient
+	cancel context.CancelFunc
+}
+
+func (w *remoteWatcher) Next() ([]*registry.ServiceInstance, error) {
+	reply, err := w.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return toServiceInstances(reply.GetInstances()), nil
+}
+
+func (w *remoteWatcher) Stop() error {
+	w.cancel()
+	return nil
+}
+
+func newInstanceFromService(service *registry.ServiceInstance) *Instance {
+	instance := &Instance{
+		Id:        service.ID,
+		Name:      service.Name,
+		Version:   service.Version,
+		Metadata:  service.Metadata,
+		Endpoints: service.Endpoints,
+	}
+	if rev, err := strconv.ParseUint(service.Metadata[MetadataRevision], 10, 64); err == nil {
+		instance.Revision = rev
+	}
+	return instance
+}
+
+func toServiceInstances(instances []*Instance) []*registry.ServiceInstance {
+	items := make([]*registry.ServiceInstance, 0, len(instances))
+	for _, in := range instances {
+		items = append(items, &registry.ServiceInstance{
+			ID:        in.GetId(),
+			Name:      in.GetName(),
+			Version:   in.GetVersion(),
+			Metadata:  in.GetMetadata(),
+			Endpoints: in.GetEndpoints(),
+		})
+	}
+	return items
+}
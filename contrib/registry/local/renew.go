@@ -0,0 +1,53 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"github.com/go-kratos/kratos/v2/registry"
+	"time"
+)
+
+// Renew refreshes the timestamp of a registered instance so it doesn't expire. Unlike
+// Register it changes nothing else and doesn't bump the revision or wake watchers.
+func (r *Registry) Renew(_ context.Context, service *registry.ServiceInstance) error {
+	if r.isClosed() {
+		return ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		return ErrReadOnly
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	entry, ok := r.entries[r.key(service.Name)]
+	if !ok || entry.ID != service.ID || r.expired(entry) {
+		return ErrInstanceNotFound
+	}
+	if !entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	return nil
+}
+
+// KeepAlive renews service every interval until ctx is done, registering it again if it
+// expired in between.
+func KeepAlive(ctx context.Context, reg *Registry, service *registry.ServiceInstance, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := reg.Renew(ctx, service)
+		if errors.Is(err, ErrInstanceNotFound) {
+			err = reg.Register(ctx, service)
+		}
+		if errors.Is(err, ErrRegistryClosed) {
+			return
+		}
+		if err != nil {
+			reg.log.Errorf("renew local registry instance <%s> error -> %s", service.ID, err.Error())
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	_ transport.Server = (*Replicator)(nil)
+)
+
+type ReplicationMode int
+
+const (
+	// ReplicatePush registers the local instances on the remote registry and deregisters the removed ones.
+	ReplicatePush ReplicationMode = iota
+	// ReplicatePull mirrors the remote registry snapshot into the local registry, even a read-only one.
+	ReplicatePull
+)
+
+// Replicator replicates a local registry to or from the RegistryAdmin service of a remote
+// registry, so the registries of a fleet can be aggregated into a central view.
+type Replicator struct {
+	reg      *Registry
+	admin    RegistryAdminClient
+	mode     ReplicationMode
+	interval time.Duration
+	pushed   map[string]*ServiceEntry
+	revision uint64
+	stop     chan struct{}
+	once     *sync.Once
+}
+
+func NewReplicator(reg *Registry, conn grpc.ClientConnInterface, mode ReplicationMode, interval time.Duration) *Replicator {
+	return &Replicator{
+		reg:      reg,
+		admin:    NewRegistryAdminClient(conn),
+		mode:     mode,
+		interval: interval,
+		pushed:   map[string]*ServiceEntry{},
+		stop:     make(chan struct{}),
+		once:     &sync.Once{},
+	}
+}
+
+func (r *Replicator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Replicate(ctx); err != nil {
+			r.reg.log.Errorf("replicate local registry error -> %s", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Replicator) Stop(_ context.Context) error {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+	return nil
+}
+
+// Replicate runs one replication round.
+func (r *Replicator) Replicate(ctx context.Context) error {
+	if r.mode == ReplicatePull {
+		return r.pull(ctx)
+	}
+	return r.push(ctx)
+}
+
+// push sends the instances changed or renewed since the last round, with their status,
+// and deregisters the removed ones. Renewals don't move the revision but must reach the
+// remote registry before its TTL expires the copy.
+func (r *Replicator) push(ctx context.Context) error {
+	current := map[string]*ServiceEntry{}
+	for _, entry := range r.reg.entriesSnapshot() {
+		current[entry.ID] = entry
+		if last, ok := r.pushed[entry.ID]; ok && last.Revision == entry.Revision && last.Timestamp.Equal(entry.Timestamp) {
+			continue
+		}
+		_, err := r.admin.Register(ctx, &RegisterRequest{Instance: newInstance(entry)})
+		if err != nil {
+			return err
+		}
+		r.pushed[entry.ID] = entry
+	}
+	for id, entry := range r.pushed {
+		if _, ok := current[id]; ok {
+			continue
+		}
+		if _, err := r.admin.Deregister(ctx, &DeregisterRequest{Name: entry.Name, Id: entry.ID}); err != nil {
+			return err
+		}
+		delete(r.pushed, id)
+	}
+	return nil
+}
+
+func (r *Replicator) pull(ctx context.Context) error {
+	reply, err := r.admin.Snapshot(ctx, &SnapshotRequest{})
+	if err != nil {
+		return err
+	}
+	if reply.GetRevision() != 0 && reply.GetRevision() == r.revision {
+		return nil
+	}
+	entries := make([]*ServiceEntry, 0, len(reply.GetInstances()))
+	for _, in := range reply.GetInstances() {
+		entries = append(entries, newServiceEntry(in))
+	}
+	r.reg.replace(entries)
+	r.revision = reply.GetRevision()
+	return nil
+}
+
+func newServiceEntry(in *Instance) *ServiceEntry {
+	entry := NewServiceEntry(in.GetId(), in.GetName(), in.GetVersion(), slices.Clone(in.GetEndpoints())...)
+	entry.Metadata = maps.Clone(in.GetMetadata())
+	delete(entry.Metadata, MetadataRevision)
+	if status, ok := entry.Metadata[MetadataStatus]; ok {
+		entry.Status = Status(status)
+		delete(entry.Metadata, MetadataStatus)
+	}
+	if in.GetTimestamp() > 0 {
+		entry.Timestamp = time.Unix(in.GetTimestamp(), 0)
+	}
+	return entry
+}
@@ -0,0 +1,72 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+	"time"
+)
+
+func newRemote(t *testing.T, opts ...Option) (*Registry, *grpc.ClientConn) {
+	t.Helper()
+	remote := New("remote", opts...)
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	RegisterRegistryAdminServer(srv, NewAdminServer(remote))
+	go func() { _ = srv.Serve(lis) }()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Stop()
+	})
+	return remote, conn
+}
+
+func TestReplicatePushKeepsHealthAndRenewals(t *testing.T) {
+	ctx := context.Background()
+	ttl := 300 * time.Millisecond
+	remote, conn := newRemote(t, WithTTL(ttl))
+	reg := New("test")
+	service := &registry.ServiceInstance{ID: "orders-1", Name: "orders", Endpoints: []string{"grpc://10.0.0.1:9000"}}
+	if err := reg.Register(ctx, service); err != nil {
+		t.Fatal(err)
+	}
+	if err := reg.SetHealth(ctx, service.ID, StatusDown); err != nil {
+		t.Fatal(err)
+	}
+	replicator := NewReplicator(reg, conn, ReplicatePush, time.Second)
+	if err := replicator.Replicate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// renew well within the local lifetime for longer than the remote TTL
+	deadline := time.Now().Add(2 * ttl)
+	for time.Now().Before(deadline) {
+		time.Sleep(ttl / 3)
+		if err := reg.Renew(ctx, service); err != nil {
+			t.Fatal(err)
+		}
+		if err := replicator.Replicate(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := remote.entriesSnapshot()
+	if len(entries) != 1 {
+		t.Fatalf("remote has %d instances, want the renewed one", len(entries))
+	}
+	if entries[0].Status != StatusDown {
+		t.Fatalf("remote status %q, want %q", entries[0].Status, StatusDown)
+	}
+	if _, ok := entries[0].Metadata[MetadataStatus]; ok {
+		t.Fatalf("remote metadata %v keeps the status key", entries[0].Metadata)
+	}
+}
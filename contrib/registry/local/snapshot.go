@@ -0,0 +1,151 @@
+package local
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Snapshot is the JSON document exported by Export and the admin API.
+type Snapshot struct {
+	Authority string          `json:"authority"`
+	Created   time.Time       `json:"created"`
+	Entries   []*ServiceEntry `json:"entries"`
+	Revision  uint64          `json:"revision"`
+}
+
+type Format int
+
+const (
+	// FormatJSON writes a Snapshot that Import can read back.
+	FormatJSON Format = iota
+	// FormatText writes a human-readable table, e.g. for support tickets.
+	FormatText
+	// FormatCompactJSON writes a Snapshot without indentation.
+	FormatCompactJSON
+)
+
+type MergeStrategy int
+
+const (
+	// MergeReplace drops every current entry before importing.
+	MergeReplace MergeStrategy = iota
+	// MergeOverwrite imports every entry, replacing current entries of the same service.
+	MergeOverwrite
+	// MergeKeepExisting only imports entries of services that aren't registered yet.
+	MergeKeepExisting
+)
+
+// Export writes the live entries of the registry in format.
+func (r *Registry) Export(_ context.Context, w io.Writer, format Format) error {
+	snapshot := r.snapshot()
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snapshot)
+	case FormatCompactJSON:
+		return json.NewEncoder(w).Encode(snapshot)
+	case FormatText:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "# authority %s, revision %d, created %s\n", snapshot.Authority, snapshot.Revision, snapshot.Created.Format(time.RFC3339))
+		fmt.Fprintln(tw, "NAME\tID\tVERSION\tSTATUS\tENDPOINTS\tUPDATED")
+		for _, entry := range snapshot.Entries {
+			updated := "-"
+			if !entry.Timestamp.IsZero() {
+				updated = entry.Timestamp.Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Name, entry.ID, entry.Version, entry.status(), strings.Join(entry.Endpoints, ","), updated)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown export format %d", format)
+	}
+}
+
+// Import reads a JSON snapshot written by Export and merges it into the registry.
+func (r *Registry) Import(_ context.Context, rd io.Reader, strategy MergeStrategy) error {
+	if r.isClosed() {
+		return ErrRegistryClosed
+	}
+	if r.opts.readOnly {
+		return ErrReadOnly
+	}
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(rd).Decode(snapshot); err != nil {
+		return err
+	}
+	if strategy == MergeReplace {
+		r.replace(snapshot.Entries)
+		return nil
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	revision := r.nextRevision()
+	for _, entry := range snapshot.Entries {
+		key := r.key(entry.Name)
+		if _, ok := r.entries[key]; ok && strategy == MergeKeepExisting {
+			continue
+		}
+		entry.Namespace = r.namespace
+		entry.Revision = revision
+		r.entries[key] = entry
+	}
+	return nil
+}
+
+// ExportFile writes a JSON snapshot to filename. A ".gz" extension selects compact,
+// gzip-compressed JSON.
+func (r *Registry) ExportFile(ctx context.Context, filename string) (err error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	if !strings.HasSuffix(filename, ".gz") {
+		return r.Export(ctx, f, FormatJSON)
+	}
+	gw := gzip.NewWriter(f)
+	if err = r.Export(ctx, gw, FormatCompactJSON); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// ImportFile imports a snapshot written by ExportFile, decompressing ".gz" files.
+func (r *Registry) ImportFile(ctx context.Context, filename string, strategy MergeStrategy) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var rd io.Reader = f
+	if strings.HasSuffix(filename, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		rd = gr
+	}
+	return r.Import(ctx, rd, strategy)
+}
+
+func (r *Registry) snapshot() *Snapshot {
+	return &Snapshot{
+		Authority: r.authority,
+		Created:   time.Now(),
+		Entries:   r.entriesSnapshot(),
+		Revision:  r.Revision(),
+	}
+}
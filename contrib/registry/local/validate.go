@@ -0,0 +1,79 @@
+package local
+
+import (
+	"fmt"
+	"github.com/go-kratos/kratos/v2/registry"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Validator checks an instance before Register stores it.
+type Validator interface {
+	Validate(service *registry.ServiceInstance) error
+}
+
+type ValidatorFunc func(service *registry.ServiceInstance) error
+
+func (f ValidatorFunc) Validate(service *registry.ServiceInstance) error {
+	return f(service)
+}
+
+// DefaultValidators run on every Register unless replaced with WithValidators.
+var DefaultValidators = []Validator{RequireName(), RequireEndpoints()}
+
+func RequireName() Validator {
+	return ValidatorFunc(func(service *registry.ServiceInstance) error {
+		if strings.TrimSpace(service.Name) == "" {
+			return fmt.Errorf("name is empty")
+		}
+		return nil
+	})
+}
+
+func RequireEndpoints() Validator {
+	return ValidatorFunc(func(service *registry.ServiceInstance) error {
+		if len(service.Endpoints) == 0 {
+			return fmt.Errorf("instance %s has no endpoints", service.ID)
+		}
+		return nil
+	})
+}
+
+// AllowSchemes rejects endpoints whose scheme isn't one of schemes, e.g. "grpc", "http".
+func AllowSchemes(schemes ...string) Validator {
+	return ValidatorFunc(func(service *registry.ServiceInstance) error {
+		for _, endpoint := range service.Endpoints {
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				return fmt.Errorf("endpoint %s: %w", endpoint, err)
+			}
+			if !slices.Contains(schemes, u.Scheme) {
+				return fmt.Errorf("endpoint %s: scheme %q not allowed", endpoint, u.Scheme)
+			}
+		}
+		return nil
+	})
+}
+
+// MetadataKeys rejects metadata keys that don't match pattern.
+func MetadataKeys(pattern *regexp.Regexp) Validator {
+	return ValidatorFunc(func(service *registry.ServiceInstance) error {
+		for key := range service.Metadata {
+			if !pattern.MatchString(key) {
+				return fmt.Errorf("metadata key %q does not match %s", key, pattern.String())
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Registry) validate(service *registry.ServiceInstance) error {
+	for _, v := range r.opts.validators {
+		if err := v.Validate(service); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidInstance, err)
+		}
+	}
+	return nil
+}
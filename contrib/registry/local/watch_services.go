@@ -0,0 +1,85 @@
+package local
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/registry"
+	"maps"
+	"time"
+)
+
+// ServicesWatcher watches a set of services with a single poller.
+type ServicesWatcher struct {
+	names    []string
+	reg      *Registry
+	interval time.Duration
+	last     map[string][]*registry.ServiceInstance
+	started  bool
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// WatchServices watches every named service at once. Next returns the instances of all of
+// them keyed by service name whenever any of them changes. Without names it watches
+// every service, including ones registered later.
+func (r *Registry) WatchServices(ctx context.Context, names ...string) (*ServicesWatcher, error) {
+	if r.isClosed() {
+		return nil, ErrRegistryClosed
+	}
+	w := &ServicesWatcher{
+		names:    names,
+		reg:      r,
+		interval: r.opts.pollInterval,
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	return w, nil
+}
+
+func (w *ServicesWatcher) Next() (map[string][]*registry.ServiceInstance, error) {
+	return w.NextWithContext(context.Background())
+}
+
+func (w *ServicesWatcher) NextWithContext(ctx context.Context) (map[string][]*registry.ServiceInstance, error) {
+	if !w.started {
+		w.started = true
+		return w.poll()
+	}
+	var services map[string][]*registry.ServiceInstance
+	err := w.reg.waitChange(w.ctx, ctx, w.interval, func() (bool, error) {
+		last := w.last
+		var err error
+		if services, err = w.poll(); err != nil {
+			return false, err
+		}
+		return !maps.EqualFunc(last, services, equalInstances), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+func (w *ServicesWatcher) Stop() error {
+	w.cancel()
+	return nil
+}
+
+func (w *ServicesWatcher) poll() (map[string][]*registry.ServiceInstance, error) {
+	names := w.names
+	if len(names) == 0 {
+		all, err := w.reg.ListServices(w.ctx)
+		if err != nil {
+			return nil, err
+		}
+		names = all
+	}
+	services := make(map[string][]*registry.ServiceInstance, len(names))
+	for _, name := range names {
+		items, err := w.reg.GetService(w.ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		services[name] = items
+	}
+	w.last = services
+	return services, nil
+}
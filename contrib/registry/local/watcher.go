@@ -1,21 +1,135 @@
 package local
 
-import "github.com/go-kratos/kratos/v2/registry"
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/registry"
+	"maps"
+	"slices"
+	"time"
+)
 
-var _ registry.Watcher = (*watcher)(nil)
+var _ ContextWatcher = (*watcher)(nil)
 
 type watcher struct {
-	name string
+	name     string
+	reg      *Registry
+	interval time.Duration
+	last     []*registry.ServiceInstance
+	started  bool
+	ctx      context.Context
+	cancel   context.CancelFunc
 }
 
 func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
-	return make([]*registry.ServiceInstance, 0), nil
+	return w.NextWithContext(context.Background())
+}
+
+// NextWithContext is Next bounded by ctx as well as the context passed to Watch.
+func (w *watcher) NextWithContext(ctx context.Context) ([]*registry.ServiceInstance, error) {
+	if !w.started {
+		w.started = true
+		return w.poll()
+	}
+	var items []*registry.ServiceInstance
+	err := w.reg.waitChange(w.ctx, ctx, w.interval, func() (bool, error) {
+		last := w.last
+		var err error
+		if items, err = w.poll(); err != nil {
+			return false, err
+		}
+		return !equalInstances(last, items), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// waitChange calls poll on every registry change and poll interval until it reports a
+// change, either context is done or the registry is closed.
+func (r *Registry) waitChange(watchCtx, ctx context.Context, interval time.Duration, poll func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		changed := r.changes.wait()
+		select {
+		case <-watchCtx.Done():
+			return watchCtx.Err()
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.closed:
+			return ErrRegistryClosed
+		case <-changed:
+		case <-ticker.C:
+		}
+		if ok, err := poll(); err != nil || ok {
+			return err
+		}
+	}
 }
 
 func (w *watcher) Stop() error {
+	w.cancel()
 	return nil
 }
 
-func newWatcher(name string) (*watcher, error) {
-	return &watcher{name: name}, nil
+func (w *watcher) poll() ([]*registry.ServiceInstance, error) {
+	if isWatchAll(w.name) {
+		return w.pollAll()
+	}
+	items, err := w.reg.GetService(w.ctx, w.name)
+	if err != nil {
+		return nil, err
+	}
+	w.last = items
+	return items, nil
+}
+
+// pollAll returns the instances of every service, ordered by service name.
+func (w *watcher) pollAll() ([]*registry.ServiceInstance, error) {
+	services, err := w.reg.ListServices(w.ctx)
+	if err != nil {
+		return nil, err
+	}
+	var items []*registry.ServiceInstance
+	for _, name := range services {
+		instances, err := w.reg.GetService(w.ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, instances...)
+	}
+	w.last = items
+	return items, nil
+}
+
+func isWatchAll(name string) bool {
+	return name == "" || name == "*"
+}
+
+func newWatcher(ctx context.Context, reg *Registry, name string, interval time.Duration) (*watcher, error) {
+	w := &watcher{
+		name:     name,
+		reg:      reg,
+		interval: interval,
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	return w, nil
+}
+
+func equalInstances(a, b []*registry.ServiceInstance) bool {
+	return slices.EqualFunc(a, b, equalInstance)
+}
+
+func equalInstance(x, y *registry.ServiceInstance) bool {
+	return x.ID == y.ID && x.Version == y.Version && sameEndpoints(x.Endpoints, y.Endpoints) &&
+		maps.Equal(x.Metadata, y.Metadata)
+}
+
+// sameEndpoints ignores order, which changes between calls with WithOrdering.
+func sameEndpoints(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
 }
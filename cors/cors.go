@@ -1,32 +1,64 @@
 package cors
 
 import (
-	"github.com/gorilla/handlers"
+	mcors "github.com/cocosip/zero/middleware/cors"
+	"github.com/go-kratos/kratos/v2/log"
 	"net/http"
+	"slices"
 )
 
+var (
+	// Deprecated: use middleware/cors.DefaultMethods.
+	DefaultMethods = mcors.DefaultMethods
+	// Deprecated: use middleware/cors.DefaultHeaders.
+	DefaultHeaders = mcors.DefaultHeaders
+)
+
+var _ mcors.CorsConfig = (*CorsOption)(nil)
+
+// gorillaStatus keeps the 200 gorilla/handlers answered preflights with; an
+// options_success_status in the configuration still takes precedence.
+var gorillaStatus = mcors.WithOptionsSuccessStatus(http.StatusOK)
+
+// CorsProfiles returns the host profiles as middleware/cors configurations.
+func (x *CorsOption) CorsProfiles() map[string]mcors.CorsConfig {
+	profiles := make(map[string]mcors.CorsConfig, len(x.GetProfiles()))
+	for host, profile := range x.GetProfiles() {
+		profiles[host] = profile
+	}
+	return profiles
+}
+
+// Deprecated: use middleware/cors.Handler with WithCorsOption.
 func Filter(opt *CorsOption) func(http.Handler) http.Handler {
-	return FilterHandler(opt.GetOrigins(), opt.GetMethods(), opt.GetHeaders(), opt.GetAllowCredentials())
+	h, err := mcors.NewHandler(gorillaStatus, mcors.WithCorsOption(opt))
+	if err == nil {
+		return h
+	}
+	log.Warnf("cors options fall back to origins, methods and headers -> %s", err.Error())
+	return gorillaHandler(opt.GetOrigins(), opt.GetMethods(), opt.GetHeaders(), opt.GetAllowCredentials())
 }
 
+// Deprecated: use middleware/cors.Handler.
 func FilterHandler(origins, methods, headers []string, allowCredentials bool) func(http.Handler) http.Handler {
-	if len(origins) == 0 {
-		origins = []string{"*"}
-	}
-	if len(methods) == 0 {
-		methods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
-	}
-	if len(headers) == 0 {
-		headers = []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With"}
-	}
+	return Filter(&CorsOption{
+		Origins:          origins,
+		Methods:          methods,
+		Headers:          headers,
+		AllowCredentials: allowCredentials,
+	})
+}
 
-	var opts = []handlers.CORSOption{
-		handlers.AllowedOrigins(origins),
-		handlers.AllowedMethods(methods),
-		handlers.AllowedHeaders(headers),
-	}
-	if allowCredentials {
-		opts = append(opts, handlers.AllowCredentials())
+// gorillaHandler keeps the options gorilla/handlers accepted. It sent credentials along
+// with "*", which browsers refuse, so they are dropped when any origin is allowed.
+func gorillaHandler(origins, methods, headers []string, allowCredentials bool) func(http.Handler) http.Handler {
+	if len(origins) == 0 || slices.Contains(origins, "*") {
+		allowCredentials = false
 	}
-	return handlers.CORS(opts...)
+	return mcors.Handler(gorillaStatus, mcors.WithCorsOption(&CorsOption{
+		Origins:          origins,
+		Methods:          methods,
+		Headers:          headers,
+		AllowCredentials: allowCredentials,
+	}))
 }
@@ -25,10 +25,16 @@ type CorsOption struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Origins          []string `protobuf:"bytes,1,rep,name=origins,proto3" json:"origins,omitempty"`
-	Methods          []string `protobuf:"bytes,2,rep,name=methods,proto3" json:"methods,omitempty"`
-	Headers          []string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
-	AllowCredentials bool     `protobuf:"varint,4,opt,name=allow_credentials,json=allowCredentials,proto3" json:"allow_credentials,omitempty"`
+	Origins              []string               `protobuf:"bytes,1,rep,name=origins,proto3" json:"origins,omitempty"`
+	Methods              []string               `protobuf:"bytes,2,rep,name=methods,proto3" json:"methods,omitempty"`
+	Headers              []string               `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	AllowCredentials     bool                   `protobuf:"varint,4,opt,name=allow_credentials,json=allowCredentials,proto3" json:"allow_credentials,omitempty"`
+	OriginPatterns       []string               `protobuf:"bytes,5,rep,name=origin_patterns,json=originPatterns,proto3" json:"origin_patterns,omitempty"`
+	MaxAge               int32                  `protobuf:"varint,6,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	OriginMaxAge         map[string]int32       `protobuf:"bytes,7,rep,name=origin_max_age,json=originMaxAge,proto3" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3" json:"origin_max_age,omitempty"`
+	Profiles             map[string]*CorsOption `protobuf:"bytes,8,rep,name=profiles,proto3" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3" json:"profiles,omitempty"`
+	OptionsPassthrough   bool                   `protobuf:"varint,9,opt,name=options_passthrough,json=optionsPassthrough,proto3" json:"options_passthrough,omitempty"`
+	OptionsSuccessStatus int32                  `protobuf:"varint,10,opt,name=options_success_status,json=optionsSuccessStatus,proto3" json:"options_success_status,omitempty"`
 }
 
 func (x *CorsOption) Reset() {
@@ -91,11 +97,53 @@ func (x *CorsOption) GetAllowCredentials() bool {
 	return false
 }
 
+func (x *CorsOption) GetOriginPatterns() []string {
+	if x != nil {
+		return x.OriginPatterns
+	}
+	return nil
+}
+
+func (x *CorsOption) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+func (x *CorsOption) GetOriginMaxAge() map[string]int32 {
+	if x != nil {
+		return x.OriginMaxAge
+	}
+	return nil
+}
+
+func (x *CorsOption) GetProfiles() map[string]*CorsOption {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *CorsOption) GetOptionsPassthrough() bool {
+	if x != nil {
+		return x.OptionsPassthrough
+	}
+	return false
+}
+
+func (x *CorsOption) GetOptionsSuccessStatus() int32 {
+	if x != nil {
+		return x.OptionsSuccessStatus
+	}
+	return 0
+}
+
 var File_cors_cors_proto protoreflect.FileDescriptor
 
 var file_cors_cors_proto_rawDesc = []byte{
 	0x0a, 0x0f, 0x63, 0x6f, 0x72, 0x73, 0x2f, 0x63, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x09, 0x7a, 0x65, 0x72, 0x6f, 0x2e, 0x63, 0x6f, 0x72, 0x73, 0x22, 0x87, 0x01, 0x0a,
+	0x6f, 0x12, 0x09, 0x7a, 0x65, 0x72, 0x6f, 0x2e, 0x63, 0x6f, 0x72, 0x73, 0x22, 0xd5, 0x04, 0x0a,
 	0x0a, 0x43, 0x6f, 0x72, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6f,
 	0x72, 0x69, 0x67, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72,
 	0x69, 0x67, 0x69, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73,
@@ -104,10 +152,38 @@ var file_cors_cors_proto_rawDesc = []byte{
 	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x6c, 0x6c,
 	0x6f, 0x77, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x43, 0x72, 0x65, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x42, 0x21, 0x5a, 0x1c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x63, 0x6f, 0x73, 0x69, 0x70, 0x2f, 0x7a, 0x65, 0x72,
-	0x6f, 0x2f, 0x63, 0x6f, 0x72, 0x73, 0xf8, 0x01, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0e, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12,
+	0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x6f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x7a, 0x65, 0x72, 0x6f, 0x2e, 0x63, 0x6f, 0x72, 0x73, 0x2e, 0x43, 0x6f, 0x72,
+	0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4d, 0x61,
+	0x78, 0x41, 0x67, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x4d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x65, 0x72, 0x6f,
+	0x2e, 0x63, 0x6f, 0x72, 0x73, 0x2e, 0x43, 0x6f, 0x72, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61,
+	0x73, 0x73, 0x74, 0x68, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x34, 0x0a, 0x16, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a,
+	0x3f, 0x0a, 0x11, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x52, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x7a, 0x65, 0x72, 0x6f, 0x2e, 0x63, 0x6f, 0x72, 0x73, 0x2e, 0x43,
+	0x6f, 0x72, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x21, 0x5a, 0x1c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x63, 0x6f, 0x73, 0x69, 0x70, 0x2f, 0x7a, 0x65, 0x72, 0x6f, 0x2f,
+	0x63, 0x6f, 0x72, 0x73, 0xf8, 0x01, 0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -122,16 +198,21 @@ func file_cors_cors_proto_rawDescGZIP() []byte {
 	return file_cors_cors_proto_rawDescData
 }
 
-var file_cors_cors_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_cors_cors_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_cors_cors_proto_goTypes = []interface{}{
 	(*CorsOption)(nil), // 0: zero.cors.CorsOption
+	nil,                // 1: zero.cors.CorsOption.OriginMaxAgeEntry
+	nil,                // 2: zero.cors.CorsOption.ProfilesEntry
 }
 var file_cors_cors_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: zero.cors.CorsOption.origin_max_age:type_name -> zero.cors.CorsOption.OriginMaxAgeEntry
+	2, // 1: zero.cors.CorsOption.profiles:type_name -> zero.cors.CorsOption.ProfilesEntry
+	0, // 2: zero.cors.CorsOption.ProfilesEntry.value:type_name -> zero.cors.CorsOption
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_cors_cors_proto_init() }
@@ -159,7 +240,7 @@ func file_cors_cors_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cors_cors_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,81 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestShimsKeepGorillaPreflightStatus(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Fatal("preflight reached the handler")
+	})
+	tests := []struct {
+		name    string
+		handler http.Handler
+		code    int
+	}{
+		{"Filter", Filter(&CorsOption{Origins: []string{"https://app.example.com"}})(next), http.StatusOK},
+		{"FilterHandler", FilterHandler([]string{"https://app.example.com"}, nil, nil, false)(next), http.StatusOK},
+		{"configured status", Filter(&CorsOption{Origins: []string{"https://app.example.com"}, OptionsSuccessStatus: http.StatusNoContent})(next), http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "http://api.example.com/v1/items", nil)
+			req.Header.Set("Origin", "https://app.example.com")
+			req.Header.Set("Access-Control-Request-Method", "POST")
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Fatalf("status %d, want %d", rec.Code, tt.code)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+				t.Fatalf("Access-Control-Allow-Origin = %q", got)
+			}
+		})
+	}
+}
+
+func TestShimsAcceptCredentialsWithoutOrigins(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name    string
+		handler func() http.Handler
+	}{
+		{"Filter", func() http.Handler { return Filter(&CorsOption{AllowCredentials: true})(next) }},
+		{"FilterHandler", func() http.Handler { return FilterHandler(nil, nil, nil, true)(next) }},
+		{"any origin", func() http.Handler { return FilterHandler([]string{"*"}, nil, nil, true)(next) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("panic: %v", r)
+				}
+			}()
+			req := httptest.NewRequest(http.MethodGet, "http://api.example.com/v1/items", nil)
+			req.Header.Set("Origin", "https://app.example.com")
+			rec := httptest.NewRecorder()
+			tt.handler().ServeHTTP(rec, req)
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+				t.Fatalf("Access-Control-Allow-Origin = %q, want *", got)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+				t.Fatalf("Access-Control-Allow-Credentials = %q, want none", got)
+			}
+		})
+	}
+}
+
+func TestShimsKeepCredentialsForListedOrigins(t *testing.T) {
+	h := FilterHandler([]string{"https://app.example.com"}, nil, nil, true)(http.NotFoundHandler())
+	req := httptest.NewRequest(http.MethodGet, "http://api.example.com/v1/items", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Fatalf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+}
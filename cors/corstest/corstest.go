@@ -0,0 +1,167 @@
+package corstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Scenario is a cross-origin request and the expected outcome.
+type Scenario struct {
+	Name string
+	// Origin is sent in the Origin header.
+	Origin string
+	// Method is the requested method; a preflight requests it, an actual request uses it.
+	Method string
+	// Path defaults to "/".
+	Path string
+	// Headers are the requested headers of a preflight.
+	Headers []string
+	// Preflight sends an OPTIONS preflight instead of the actual request.
+	Preflight bool
+	// Allowed expects the response to allow the origin.
+	Allowed bool
+	// Credentials expects Access-Control-Allow-Credentials: true when allowed.
+	Credentials bool
+}
+
+// Run runs every scenario as a subtest against a httptest server serving handler.
+func Run(t *testing.T, handler http.Handler, scenarios []Scenario) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	for _, sc := range scenarios {
+		t.Run(sc.Name, func(t *testing.T) {
+			t.Helper()
+			resp := Do(t, srv.URL, sc)
+			defer resp.Body.Close()
+			check(t, sc, resp)
+		})
+	}
+}
+
+// Do sends the request of sc to baseURL.
+func Do(t testing.TB, baseURL string, sc Scenario) *http.Response {
+	t.Helper()
+	path := sc.Path
+	if path == "" {
+		path = "/"
+	}
+	method := sc.Method
+	if sc.Preflight {
+		method = http.MethodOptions
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(baseURL, "/")+path, nil)
+	if err != nil {
+		t.Fatalf("corstest: new request: %s", err.Error())
+	}
+	req.Header.Set("Origin", sc.Origin)
+	if sc.Preflight {
+		req.Header.Set("Access-Control-Request-Method", sc.Method)
+		if len(sc.Headers) > 0 {
+			req.Header.Set("Access-Control-Request-Headers", strings.Join(sc.Headers, ", "))
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("corstest: %s %s: %s", method, path, err.Error())
+	}
+	return resp
+}
+
+// AssertPreflightAllowed fails t unless handler allows a preflight of method from origin.
+func AssertPreflightAllowed(t testing.TB, handler http.Handler, origin, method string, headers ...string) {
+	t.Helper()
+	assert(t, handler, Scenario{Origin: origin, Method: method, Headers: headers, Preflight: true, Allowed: true})
+}
+
+// AssertPreflightRejected fails t if handler allows a preflight of method from origin.
+func AssertPreflightRejected(t testing.TB, handler http.Handler, origin, method string, headers ...string) {
+	t.Helper()
+	assert(t, handler, Scenario{Origin: origin, Method: method, Headers: headers, Preflight: true})
+}
+
+// AssertAllowed fails t unless handler allows an actual request of method from origin.
+func AssertAllowed(t testing.TB, handler http.Handler, origin, method string) {
+	t.Helper()
+	assert(t, handler, Scenario{Origin: origin, Method: method, Allowed: true})
+}
+
+// AssertRejected fails t if handler allows an actual request of method from origin.
+func AssertRejected(t testing.TB, handler http.Handler, origin, method string) {
+	t.Helper()
+	assert(t, handler, Scenario{Origin: origin, Method: method})
+}
+
+func assert(t testing.TB, handler http.Handler, sc Scenario) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	resp := Do(t, srv.URL, sc)
+	defer resp.Body.Close()
+	check(t, sc, resp)
+}
+
+func check(t testing.TB, sc Scenario, resp *http.Response) {
+	t.Helper()
+	allowOrigin := resp.Header.Get("Access-Control-Allow-Origin")
+	allowed := allowOrigin == "*" || allowOrigin == sc.Origin
+	if sc.Preflight && allowed {
+		allowed = allowsMethod(resp.Header.Get("Access-Control-Allow-Methods"), sc.Method) &&
+			allowsHeaders(resp.Header.Get("Access-Control-Allow-Headers"), sc.Headers)
+	}
+	switch {
+	case sc.Allowed && !allowed:
+		t.Errorf("corstest: %s from %s rejected, status %d, headers %v", describe(sc), sc.Origin, resp.StatusCode, corsHeaders(resp.Header))
+	case !sc.Allowed && allowed:
+		t.Errorf("corstest: %s from %s allowed, headers %v", describe(sc), sc.Origin, corsHeaders(resp.Header))
+	case sc.Allowed && sc.Credentials && resp.Header.Get("Access-Control-Allow-Credentials") != "true":
+		t.Errorf("corstest: %s from %s doesn't allow credentials", describe(sc), sc.Origin)
+	case sc.Allowed && sc.Preflight && (resp.StatusCode < 200 || resp.StatusCode > 299):
+		t.Errorf("corstest: %s from %s answered with status %d", describe(sc), sc.Origin, resp.StatusCode)
+	}
+}
+
+func describe(sc Scenario) string {
+	if sc.Preflight {
+		return "preflight of " + sc.Method
+	}
+	return sc.Method + " request"
+}
+
+func allowsMethod(list, method string) bool {
+	// simple methods need no explicit allowance
+	if method == http.MethodGet || method == http.MethodHead || method == http.MethodPost {
+		return true
+	}
+	return contains(list, method)
+}
+
+func allowsHeaders(list string, headers []string) bool {
+	for _, header := range headers {
+		if !contains(list, header) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list, value string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item == "*" || strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func corsHeaders(h http.Header) map[string]string {
+	headers := make(map[string]string)
+	for key := range h {
+		if strings.HasPrefix(key, "Access-Control-") || key == "Vary" {
+			headers[key] = h.Get(key)
+		}
+	}
+	return headers
+}
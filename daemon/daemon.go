@@ -4,6 +4,7 @@ import (
 	ud "github.com/cocosip/utils/daemon"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
+	"os"
 )
 
 var (
@@ -26,10 +27,18 @@ func (s *KratosService) Name() string {
 	return s.app.Name()
 }
 
-func (s *KratosService) Run() error {
+func (s *KratosService) Run() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverError(r)
+		}
+	}()
 	return s.app.Run()
 }
 
+// HandleError logs err and exits with the code of its failure class, see ExitCode.
 func (s *KratosService) HandleError(err error) {
-	s.log.Errorf("kratos service <%s> error -> %s", s.app.Name(), err.Error())
+	code := ExitCode(err)
+	s.log.Errorf("kratos service <%s> error, exit code %d -> %s", s.app.Name(), code, err.Error())
+	os.Exit(code)
 }
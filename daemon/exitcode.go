@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// Exit codes follow sysexits.h so service managers can tell permanent failures from
+// transient ones, e.g. systemd RestartPreventExitStatus=78 or Windows Service Recovery
+// actions keyed on the exit code:
+//
+//	0   clean shutdown
+//	1   unclassified failure
+//	69  registry unreachable (transient, restart)
+//	70  panic (software bug, restart with backoff)
+//	75  listen address in use (transient, restart)
+//	78  invalid configuration (permanent, do not restart)
+const (
+	ExitOK                  = 0
+	ExitFailure             = 1
+	ExitRegistryUnreachable = 69
+	ExitPanic               = 70
+	ExitAddressInUse        = 75
+	ExitInvalidConfig       = 78
+)
+
+var (
+	ErrInvalidConfig       = errors.New("invalid configuration")
+	ErrRegistryUnreachable = errors.New("registry unreachable")
+	ErrPanic               = errors.New("panic")
+)
+
+// ExitCode maps err to the exit code of its failure class. Wrap errors with
+// ErrInvalidConfig or ErrRegistryUnreachable to classify them.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrInvalidConfig):
+		return ExitInvalidConfig
+	case errors.Is(err, ErrRegistryUnreachable):
+		return ExitRegistryUnreachable
+	case errors.Is(err, ErrPanic):
+		return ExitPanic
+	case errors.Is(err, syscall.EADDRINUSE):
+		return ExitAddressInUse
+	default:
+		return ExitFailure
+	}
+}
+
+func recoverError(r interface{}) error {
+	return fmt.Errorf("%w: %v", ErrPanic, r)
+}
@@ -0,0 +1,210 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultTimeout = 3 * time.Second
+
+// Aggregator serves composite endpoints: each request fans out to the configured backends,
+// resolved through discovery, and their JSON responses are merged into one document.
+//
+// A backend's response is placed under its name unless it has a mapping, whose entries
+// copy dotted source paths of the response to dotted target paths of the result, e.g.
+// "user.name": "profile.display_name" or "first_order": "orders.0". The composite timeout,
+// in milliseconds, bounds the whole fan-out; failed optional backends are listed under "_failed".
+type Aggregator struct {
+	opt    *GatewayOption
+	dis    registry.Discovery
+	client *http.Client
+	log    *log.Helper
+}
+
+func NewAggregator(opt *GatewayOption, dis registry.Discovery, logger log.Logger) *Aggregator {
+	return &Aggregator{
+		opt:    opt,
+		dis:    dis,
+		client: &http.Client{},
+		log:    log.NewHelper(logger),
+	}
+}
+
+// Handler returns a handler serving every composite endpoint.
+func (a *Aggregator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, composite := range a.opt.GetComposites() {
+		c := composite
+		mux.HandleFunc("GET "+c.GetPath(), func(w http.ResponseWriter, req *http.Request) {
+			a.serve(w, req, c)
+		})
+	}
+	return mux
+}
+
+func (a *Aggregator) serve(w http.ResponseWriter, req *http.Request, c *GatewayOption_Composite) {
+	timeout := defaultTimeout
+	if c.GetTimeout() > 0 {
+		timeout = time.Duration(c.GetTimeout()) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	defer cancel()
+
+	type result struct {
+		backend *GatewayOption_Backend
+		body    interface{}
+		err     error
+	}
+	results := make([]result, len(c.GetBackends()))
+	wg := &sync.WaitGroup{}
+	for i, backend := range c.GetBackends() {
+		wg.Add(1)
+		go func(i int, backend *GatewayOption_Backend) {
+			defer wg.Done()
+			body, err := a.call(ctx, backend, req)
+			results[i] = result{backend: backend, body: body, err: err}
+		}(i, backend)
+	}
+	wg.Wait()
+
+	merged := map[string]interface{}{}
+	var failed []string
+	for _, r := range results {
+		if r.err != nil {
+			a.log.Errorf("aggregate %s backend <%s> error -> %s", c.GetPath(), r.backend.GetName(), r.err.Error())
+			if !r.backend.GetOptional() {
+				writeJSON(w, http.StatusBadGateway, map[string]string{"error": fmt.Sprintf("backend %s: %s", r.backend.GetName(), r.err.Error())})
+				return
+			}
+			failed = append(failed, r.backend.GetName())
+			continue
+		}
+		if len(r.backend.GetMapping()) == 0 {
+			setPath(merged, r.backend.GetName(), r.body)
+			continue
+		}
+		for target, source := range r.backend.GetMapping() {
+			if v, ok := getPath(r.body, source); ok {
+				setPath(merged, target, v)
+			}
+		}
+	}
+	if len(failed) > 0 {
+		merged["_failed"] = failed
+	}
+	writeJSON(w, http.StatusOK, merged)
+}
+
+func (a *Aggregator) call(ctx context.Context, backend *GatewayOption_Backend, in *http.Request) (interface{}, error) {
+	base, err := a.endpoint(ctx, backend.GetService())
+	if err != nil {
+		return nil, err
+	}
+	method := backend.GetMethod()
+	if method == "" {
+		method = http.MethodGet
+	}
+	target := base + backend.GetPath()
+	if in.URL.RawQuery != "" {
+		target += "?" + in.URL.RawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if auth := in.Header.Get("Authorization"); auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s returned %s: %s", backend.GetService(), resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var body interface{}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// endpoint picks a random http endpoint of service.
+func (a *Aggregator) endpoint(ctx context.Context, service string) (string, error) {
+	instances, err := a.dis.GetService(ctx, service)
+	if err != nil {
+		return "", err
+	}
+	var endpoints []string
+	for _, instance := range instances {
+		for _, e := range instance.Endpoints {
+			u, err := url.Parse(e)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				continue
+			}
+			endpoints = append(endpoints, u.Scheme+"://"+u.Host)
+		}
+	}
+	if len(endpoints) == 0 {
+		return "", fmt.Errorf("no http endpoint for service %s", service)
+	}
+	return endpoints[rand.IntN(len(endpoints))], nil
+}
+
+func getPath(v interface{}, path string) (interface{}, bool) {
+	if path == "" || path == "." {
+		return v, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func setPath(m map[string]interface{}, path string, v interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[key] = next
+		}
+		m = next
+	}
+	m[keys[len(keys)-1]] = v
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
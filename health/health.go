@@ -0,0 +1,155 @@
+package health
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	_ transport.Server = (*Reporter)(nil)
+)
+
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+
+	// MetadataKey carries the aggregated health; each check is published as MetadataKey + "." + name.
+	MetadataKey = "health"
+
+	defaultInterval = 10 * time.Second
+	defaultTimeout  = 3 * time.Second
+)
+
+// Check reports whether a dependency is healthy.
+type Check func(ctx context.Context) error
+
+type Option func(r *Reporter)
+
+// WithCheck adds a named dependency check. The instance is DOWN when any check fails.
+func WithCheck(name string, check Check) Option {
+	return func(r *Reporter) {
+		r.checks[name] = check
+	}
+}
+
+func WithInterval(interval time.Duration) Option {
+	return func(r *Reporter) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithTimeout bounds each check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Reporter) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
+
+// Reporter runs dependency checks on a cadence and publishes the results into the registry
+// metadata of an instance, so clients can avoid instances whose dependencies are down.
+type Reporter struct {
+	reg      registry.Registrar
+	instance *registry.ServiceInstance
+	checks   map[string]Check
+	interval time.Duration
+	timeout  time.Duration
+	last     map[string]string
+	log      *log.Helper
+	stop     chan struct{}
+	once     *sync.Once
+	m        *sync.Mutex
+}
+
+func NewReporter(reg registry.Registrar, instance *registry.ServiceInstance, logger log.Logger, opts ...Option) *Reporter {
+	r := &Reporter{
+		reg:      reg,
+		instance: instance,
+		checks:   map[string]Check{},
+		interval: defaultInterval,
+		timeout:  defaultTimeout,
+		log:      log.NewHelper(logger),
+		stop:     make(chan struct{}),
+		once:     &sync.Once{},
+		m:        &sync.Mutex{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Reporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Report(ctx); err != nil {
+			r.log.Errorf("report health error -> %s", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reporter) Stop(_ context.Context) error {
+	r.once.Do(func() {
+		close(r.stop)
+	})
+	return nil
+}
+
+// Check runs every check and returns the resulting metadata.
+func (r *Reporter) Check(ctx context.Context) map[string]string {
+	md := map[string]string{MetadataKey: StatusUp}
+	names := slices.Sorted(maps.Keys(r.checks))
+	for _, name := range names {
+		cctx, cancel := context.WithTimeout(ctx, r.timeout)
+		err := r.checks[name](cctx)
+		cancel()
+		if err != nil {
+			md[MetadataKey] = StatusDown
+			md[MetadataKey+"."+name] = StatusDown + ": " + err.Error()
+			continue
+		}
+		md[MetadataKey+"."+name] = StatusUp
+	}
+	return md
+}
+
+// Report runs the checks and re-registers the instance when the results changed.
+func (r *Reporter) Report(ctx context.Context) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+	md := r.Check(ctx)
+	if maps.Equal(md, r.last) {
+		return nil
+	}
+	instance := *r.instance
+	instance.Metadata = maps.Clone(r.instance.Metadata)
+	if instance.Metadata == nil {
+		instance.Metadata = map[string]string{}
+	}
+	maps.Copy(instance.Metadata, md)
+	if err := r.reg.Register(ctx, &instance); err != nil {
+		return err
+	}
+	if md[MetadataKey] != r.last[MetadataKey] {
+		r.log.Infof("instance <%s> health changed to %s", r.instance.ID, md[MetadataKey])
+	}
+	r.last = md
+	return nil
+}
@@ -0,0 +1,176 @@
+package log
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/transport"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	_ io.WriteCloser   = (*AsyncWriter)(nil)
+	_ transport.Server = (*AsyncWriter)(nil)
+)
+
+const defaultAsyncBufferSize = 8192
+
+// AsyncWriter queues writes in a bounded ring buffer flushed to the underlying writer by
+// a background goroutine, so logging never blocks on I/O. When the buffer is full the
+// oldest entries are overwritten and counted as dropped. Register it as a kratos server,
+// or call Close, to flush on shutdown. Writes after Close go straight to the underlying
+// writer until it is closed, and are dropped after that.
+type AsyncWriter struct {
+	w       io.Writer
+	ring    [][]byte
+	head    int
+	size    int
+	dropped *atomic.Int64
+	signal  chan struct{}
+	flushed *sync.Cond
+	writing bool
+	closed  bool
+	done    chan struct{}
+	m       *sync.Mutex
+	// wm serializes writes to w with closing it
+	wm      *sync.Mutex
+	wclosed bool
+}
+
+// NewAsyncWriter wraps w with a buffer of size entries, 8192 when size <= 0, and starts
+// the flusher.
+func NewAsyncWriter(w io.Writer, size int) *AsyncWriter {
+	if size <= 0 {
+		size = defaultAsyncBufferSize
+	}
+	m := &sync.Mutex{}
+	a := &AsyncWriter{
+		w:       w,
+		ring:    make([][]byte, size),
+		dropped: &atomic.Int64{},
+		signal:  make(chan struct{}, 1),
+		flushed: sync.NewCond(m),
+		done:    make(chan struct{}),
+		m:       m,
+		wm:      &sync.Mutex{},
+	}
+	go a.run()
+	return a
+}
+
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	// p may be reused by the caller once Write returns
+	entry := append([]byte(nil), p...)
+	a.m.Lock()
+	if a.closed {
+		a.m.Unlock()
+		return a.writeThrough(p)
+	}
+	if a.size == len(a.ring) {
+		a.head = (a.head + 1) % len(a.ring)
+		a.size--
+		a.dropped.Add(1)
+	}
+	a.ring[(a.head+a.size)%len(a.ring)] = entry
+	a.size++
+	// signal under the lock, Close closes the channel only after marking closed
+	select {
+	case a.signal <- struct{}{}:
+	default:
+	}
+	a.m.Unlock()
+	return len(p), nil
+}
+
+// Dropped returns the number of entries overwritten because the buffer was full, or
+// written after the underlying writer was closed.
+func (a *AsyncWriter) Dropped() int64 {
+	return a.dropped.Load()
+}
+
+// Flush blocks until the entries buffered so far are written.
+func (a *AsyncWriter) Flush() {
+	a.m.Lock()
+	defer a.m.Unlock()
+	for (a.size > 0 || a.writing) && !a.closed {
+		select {
+		case a.signal <- struct{}{}:
+		default:
+		}
+		a.flushed.Wait()
+	}
+}
+
+// Start does nothing, the flusher runs from NewAsyncWriter; Stop flushes and closes.
+func (a *AsyncWriter) Start(context.Context) error {
+	return nil
+}
+
+func (a *AsyncWriter) Stop(context.Context) error {
+	return a.Close()
+}
+
+// Close flushes the buffer, stops the flusher and closes the underlying writer when it
+// is an io.Closer other than os.Stdout and os.Stderr.
+func (a *AsyncWriter) Close() error {
+	a.m.Lock()
+	if a.closed {
+		a.m.Unlock()
+		return nil
+	}
+	a.closed = true
+	a.m.Unlock()
+	close(a.signal)
+	<-a.done
+	a.wm.Lock()
+	defer a.wm.Unlock()
+	a.wclosed = true
+	if c, ok := a.w.(io.Closer); ok && a.w != os.Stdout && a.w != os.Stderr {
+		return c.Close()
+	}
+	return nil
+}
+
+func (a *AsyncWriter) writeThrough(p []byte) (int, error) {
+	a.wm.Lock()
+	defer a.wm.Unlock()
+	if a.wclosed {
+		a.dropped.Add(1)
+		return len(p), nil
+	}
+	return a.w.Write(p)
+}
+
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+	for range a.signal {
+		a.drain()
+	}
+	a.drain()
+}
+
+func (a *AsyncWriter) drain() {
+	for {
+		a.m.Lock()
+		if a.size == 0 {
+			a.writing = false
+			a.flushed.Broadcast()
+			a.m.Unlock()
+			return
+		}
+		batch := make([][]byte, 0, a.size)
+		for ; a.size > 0; a.size-- {
+			batch = append(batch, a.ring[a.head])
+			a.ring[a.head] = nil
+			a.head = (a.head + 1) % len(a.ring)
+		}
+		a.writing = true
+		a.m.Unlock()
+		a.wm.Lock()
+		for _, entry := range batch {
+			_, _ = a.w.Write(entry)
+		}
+		a.wm.Unlock()
+	}
+}
@@ -0,0 +1,98 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+)
+
+type closeRecorder struct {
+	buf    bytes.Buffer
+	closed bool
+	m      sync.Mutex
+}
+
+func (w *closeRecorder) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	return w.buf.Write(p)
+}
+
+func (w *closeRecorder) Close() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	w.closed = true
+	return nil
+}
+
+func TestAsyncWriterClose(t *testing.T) {
+	w := &closeRecorder{}
+	a := NewAsyncWriter(w, 4)
+	if _, err := a.Write([]byte("before\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.buf.String(); got != "before\n" {
+		t.Fatalf("flushed %q, want %q", got, "before\n")
+	}
+	if !w.closed {
+		t.Fatal("underlying writer not closed")
+	}
+	if _, err := a.Write([]byte("after\n")); err != nil {
+		t.Fatalf("write after close: %v", err)
+	}
+	if a.Dropped() != 1 {
+		t.Fatalf("dropped %d, want 1", a.Dropped())
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAsyncWriterKeepsStdout(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	a := NewAsyncWriter(os.Stdout, 4)
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("x")); err != nil {
+		t.Fatalf("stdout closed by AsyncWriter: %v", err)
+	}
+}
+
+func TestAsyncWriterConcurrentClose(t *testing.T) {
+	w := &closeRecorder{}
+	a := NewAsyncWriter(w, 16)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := a.Write([]byte("line\n")); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+}
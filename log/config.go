@@ -0,0 +1,45 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"google.golang.org/protobuf/proto"
+	"os"
+)
+
+// NewFromConfig builds the logger described by the LogOption stored at key of c: its
+// outputs tagged with the service of meta, filtered by level, modules, sampling, dedupe
+// and redaction. The batching outputs run in the background until the returned cleanup
+// function flushes and closes every output. It sets DefaultLevelController to the level
+// of the option.
+func NewFromConfig(c config.Config, key string, meta *registry.ServiceInstance) (log.Logger, func(), error) {
+	opt := &LogOption{}
+	if err := c.Value(key).Scan(opt); err != nil {
+		return nil, nil, fmt.Errorf("scan log option %s: %w", key, err)
+	}
+	if meta == nil {
+		meta = &registry.ServiceInstance{}
+	}
+	DefaultLevelController.SetLevel(log.ParseLevel(opt.GetLevel()))
+	// outputs filter by their own level only, the controller and module levels by opt's
+	outputs := proto.Clone(opt).(*LogOption)
+	outputs.Level = log.LevelDebug.String()
+	logger, tee, err := NewTeeLogger(outputs, meta.ID, meta.Name, meta.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_ = tee.Start(ctx)
+	}()
+	cleanup := func() {
+		cancel()
+		if err := tee.Stop(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "close log outputs error -> %s\n", err.Error())
+		}
+	}
+	return newFilteredLogger(logger, opt, DefaultLevelController), cleanup, nil
+}
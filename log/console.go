@@ -0,0 +1,140 @@
+package log
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ log.Logger = (*ConsoleLogger)(nil)
+)
+
+const (
+	consoleMessageWidth = 40
+	colorReset          = "\x1b[0m"
+	colorGray           = "\x1b[90m"
+)
+
+var levelColors = map[log.Level]string{
+	log.LevelDebug: "\x1b[36m",
+	log.LevelInfo:  "\x1b[32m",
+	log.LevelWarn:  "\x1b[33m",
+	log.LevelError: "\x1b[31m",
+	log.LevelFatal: "\x1b[35m",
+}
+
+// ConsoleLogger writes human-friendly lines for local development: time, colored level,
+// shortened caller and message first, then the remaining non-empty fields as key=value.
+type ConsoleLogger struct {
+	w     io.Writer
+	color bool
+	pool  *sync.Pool
+	m     *sync.Mutex
+}
+
+// NewConsoleLogger returns a console logger; levels are colored when w is a terminal and
+// NO_COLOR is unset.
+func NewConsoleLogger(w io.Writer) *ConsoleLogger {
+	return &ConsoleLogger{
+		w:     w,
+		color: isTerminal(w) && os.Getenv("NO_COLOR") == "",
+		pool: &sync.Pool{
+			New: func() interface{} { return new(bytes.Buffer) },
+		},
+		m: &sync.Mutex{},
+	}
+}
+
+func (l *ConsoleLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	var ts, caller, msg string
+	fields := make([]interface{}, 0, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		key, value := fmt.Sprint(keyvals[i]), fmt.Sprint(keyvals[i+1])
+		switch key {
+		case "ts":
+			ts = shortTime(value)
+		case "caller":
+			caller = shortCaller(value)
+		case log.DefaultMessageKey:
+			msg = value
+		default:
+			if value != "" {
+				fields = append(fields, key, value)
+			}
+		}
+	}
+	buf := l.pool.Get().(*bytes.Buffer)
+	defer l.pool.Put(buf)
+	buf.Reset()
+	if ts != "" {
+		l.paint(buf, colorGray, ts)
+		buf.WriteByte(' ')
+	}
+	l.paint(buf, levelColors[level], fmt.Sprintf("%-5s", level.String()))
+	if caller != "" {
+		buf.WriteByte(' ')
+		l.paint(buf, colorGray, caller)
+	}
+	if msg != "" || len(fields) > 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(msg)
+	}
+	if len(fields) > 0 && len(msg) < consoleMessageWidth {
+		buf.WriteString(strings.Repeat(" ", consoleMessageWidth-len(msg)))
+	}
+	for i := 0; i < len(fields); i += 2 {
+		buf.WriteByte(' ')
+		l.paint(buf, colorGray, fields[i].(string)+"=")
+		buf.WriteString(fields[i+1].(string))
+	}
+	buf.WriteByte('\n')
+	l.m.Lock()
+	defer l.m.Unlock()
+	_, err := l.w.Write(buf.Bytes())
+	return err
+}
+
+func (l *ConsoleLogger) paint(buf *bytes.Buffer, color, s string) {
+	if !l.color || color == "" {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteString(color)
+	buf.WriteString(s)
+	buf.WriteString(colorReset)
+}
+
+// shortTime keeps the time of day of RFC 3339 timestamps.
+func shortTime(ts string) string {
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		return t.Format("15:04:05.000")
+	}
+	return ts
+}
+
+// shortCaller keeps the last directory and file of a caller path.
+func shortCaller(caller string) string {
+	parts := strings.Split(caller, "/")
+	if len(parts) > 2 {
+		return strings.Join(parts[len(parts)-2:], "/")
+	}
+	return caller
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
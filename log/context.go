@@ -0,0 +1,73 @@
+package log
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+const (
+	TenantIDKey  = "tenant.id"
+	UserIDKey    = "user.id"
+	RequestIDKey = "request.id"
+)
+
+type contextKey int
+
+const (
+	tenantIDContextKey contextKey = iota
+	userIDContextKey
+	requestIDContextKey
+)
+
+func WithTenantID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantIDContextKey, id)
+}
+
+// TenantIDFromContext returns the tenant id stored by WithTenantID, falling back to the
+// "tenant" baggage member propagated by middleware/baggage.
+func TenantIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(tenantIDContextKey).(string); ok {
+		return id
+	}
+	return baggage.FromContext(ctx).Member("tenant").Value()
+}
+
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDContextKey, id)
+}
+
+func UserIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(userIDContextKey).(string)
+	return id
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// TenantID returns a valuer of the tenant id of the context, empty without one.
+func TenantID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		return TenantIDFromContext(ctx)
+	}
+}
+
+// UserID returns a valuer of the user id of the context, empty without one.
+func UserID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		return UserIDFromContext(ctx)
+	}
+}
+
+// RequestID returns a valuer of the request id of the context, empty without one.
+func RequestID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		return RequestIDFromContext(ctx)
+	}
+}
@@ -0,0 +1,72 @@
+package log
+
+import (
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"sync"
+	"time"
+)
+
+var _ log.Logger = (*dedupeLogger)(nil)
+
+// dedupeLogger collapses identical lines logged within a window into a single
+// "last message repeated N times" entry, so runaway error loops don't flood the log file.
+type dedupeLogger struct {
+	logger log.Logger
+	window time.Duration
+	last   string
+	level  log.Level
+	since  time.Time
+	count  int
+	timer  *time.Timer
+	m      *sync.Mutex
+}
+
+func NewDedupeLogger(logger log.Logger, window time.Duration) log.Logger {
+	if window <= 0 {
+		return logger
+	}
+	return &dedupeLogger{
+		logger: logger,
+		window: window,
+		m:      &sync.Mutex{},
+	}
+}
+
+func (d *dedupeLogger) Log(level log.Level, keyvals ...interface{}) error {
+	key := level.String() + fmt.Sprint(keyvals...)
+	d.m.Lock()
+	defer d.m.Unlock()
+	now := time.Now()
+	if key == d.last && now.Sub(d.since) < d.window {
+		d.count++
+		if d.timer == nil {
+			d.timer = time.AfterFunc(d.window-now.Sub(d.since), d.flush)
+		}
+		return nil
+	}
+	d.summarize()
+	d.last = key
+	d.level = level
+	d.since = now
+	return d.logger.Log(level, keyvals...)
+}
+
+func (d *dedupeLogger) flush() {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.summarize()
+	d.last = ""
+}
+
+func (d *dedupeLogger) summarize() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if d.count == 0 {
+		return
+	}
+	_ = d.logger.Log(d.level, log.DefaultMessageKey, fmt.Sprintf("last message repeated %d times", d.count))
+	d.count = 0
+}
@@ -0,0 +1,256 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ log.Logger       = (*ElasticsearchLogger)(nil)
+	_ transport.Server = (*ElasticsearchLogger)(nil)
+)
+
+const (
+	defaultESIndex         = "logs-{2006.01.02}"
+	defaultESBatchSize     = 500
+	defaultESFlushInterval = 5 * time.Second
+	defaultESQueueSize     = 10000
+	defaultESMaxRetries    = 3
+	defaultESBackoff       = 500 * time.Millisecond
+	// esEnqueueTimeout bounds how long Log blocks on a full queue before dropping.
+	esEnqueueTimeout = 100 * time.Millisecond
+)
+
+var esIndexLayout = regexp.MustCompile(`\{([^}]*)\}`)
+
+// ElasticsearchLogger bulk-indexes log records as documents into Elasticsearch or
+// OpenSearch. Records are queued; when the queue is full Log blocks briefly, then
+// drops the record and counts it. Bulk requests failing with 429 or 5xx are retried
+// with backoff. It must be started as a kratos server to index anything; combine it
+// with other outputs through log.MultiLogger.
+type ElasticsearchLogger struct {
+	opt     *LogOption_ElasticsearchOption
+	client  *http.Client
+	queue   chan map[string]interface{}
+	dropped *atomic.Int64
+	stop    chan struct{}
+	done    chan struct{}
+	once    *sync.Once
+	// started is claimed by the first Start, or by a Stop without Start
+	started *atomic.Bool
+}
+
+func NewElasticsearchLogger(opt *LogOption_ElasticsearchOption) *ElasticsearchLogger {
+	size := int(opt.GetQueueSize())
+	if size <= 0 {
+		size = defaultESQueueSize
+	}
+	return &ElasticsearchLogger{
+		opt:     opt,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		queue:   make(chan map[string]interface{}, size),
+		dropped: &atomic.Int64{},
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		once:    &sync.Once{},
+		started: &atomic.Bool{},
+	}
+}
+
+func (l *ElasticsearchLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	doc := make(map[string]interface{}, len(keyvals)/2+2)
+	doc["@timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+	doc["level"] = level.String()
+	for i := 0; i < len(keyvals); i += 2 {
+		v := keyvals[i+1]
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		doc[fmt.Sprint(keyvals[i])] = v
+	}
+	select {
+	case l.queue <- doc:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(esEnqueueTimeout)
+	defer timer.Stop()
+	select {
+	case l.queue <- doc:
+	case <-timer.C:
+		l.dropped.Add(1)
+	}
+	return nil
+}
+
+// Dropped returns the number of records dropped because the queue was full.
+func (l *ElasticsearchLogger) Dropped() int64 {
+	return l.dropped.Load()
+}
+
+func (l *ElasticsearchLogger) Start(ctx context.Context) error {
+	if !l.started.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer close(l.done)
+	ticker := time.NewTicker(l.flushInterval())
+	defer ticker.Stop()
+	batch := make([]map[string]interface{}, 0, l.batchSize())
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := l.bulk(context.Background(), batch); err != nil {
+			fmt.Fprintf(os.Stderr, "index logs to elasticsearch error -> %s\n", err.Error())
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			return nil
+		case <-l.stop:
+			// drain what was queued before Stop
+			for {
+				select {
+				case doc := <-l.queue:
+					batch = append(batch, doc)
+					if len(batch) >= l.batchSize() {
+						flush()
+					}
+				default:
+					flush()
+					return nil
+				}
+			}
+		case doc := <-l.queue:
+			batch = append(batch, doc)
+			if len(batch) >= l.batchSize() {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// Stop flushes the queued records and waits for Start to return, or for ctx. Without
+// Start nothing was indexed and it returns at once.
+func (l *ElasticsearchLogger) Stop(ctx context.Context) error {
+	l.once.Do(func() {
+		close(l.stop)
+	})
+	if l.started.CompareAndSwap(false, true) {
+		close(l.done)
+		return nil
+	}
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *ElasticsearchLogger) bulk(ctx context.Context, docs []map[string]interface{}) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, doc := range docs {
+		_ = enc.Encode(map[string]map[string]string{"create": {"_index": l.index(time.Now())}})
+		if err := enc.Encode(doc); err != nil {
+			// index the record as text when a value can't be encoded
+			_ = enc.Encode(map[string]string{"message": fmt.Sprint(doc)})
+		}
+	}
+	backoff := defaultESBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := l.post(ctx, body.Bytes())
+		if err == nil || !retry || attempt >= l.maxRetries() {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (l *ElasticsearchLogger) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(l.opt.GetEndpoint(), "/")+"/_bulk", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if l.opt.GetUsername() != "" {
+		req.SetBasicAuth(l.opt.GetUsername(), l.opt.GetPassword())
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return true, fmt.Errorf("elasticsearch bulk: unexpected status %s", resp.Status)
+	}
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, fmt.Errorf("elasticsearch bulk: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var reply struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err == nil && reply.Errors {
+		return false, fmt.Errorf("elasticsearch bulk: some records were rejected")
+	}
+	return false, nil
+}
+
+// index expands the Go time layouts in braces of the index pattern, e.g. logs-{2006.01.02}.
+func (l *ElasticsearchLogger) index(t time.Time) string {
+	pattern := l.opt.GetIndex()
+	if pattern == "" {
+		pattern = defaultESIndex
+	}
+	return esIndexLayout.ReplaceAllStringFunc(pattern, func(layout string) string {
+		return t.UTC().Format(layout[1 : len(layout)-1])
+	})
+}
+
+func (l *ElasticsearchLogger) batchSize() int {
+	if size := int(l.opt.GetBatchSize()); size > 0 {
+		return size
+	}
+	return defaultESBatchSize
+}
+
+func (l *ElasticsearchLogger) flushInterval() time.Duration {
+	if interval := l.opt.GetFlushInterval(); interval > 0 {
+		return time.Duration(interval) * time.Second
+	}
+	return defaultESFlushInterval
+}
+
+func (l *ElasticsearchLogger) maxRetries() int {
+	if retries := int(l.opt.GetMaxRetries()); retries > 0 {
+		return retries
+	}
+	return defaultESMaxRetries
+}
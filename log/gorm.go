@@ -0,0 +1,130 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/cocosip/utils/database"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	glog "gorm.io/gorm/logger"
+	"os"
+	"time"
+)
+
+var (
+	_ glog.Interface = (*traceGormLogger)(nil)
+	_ glog.Interface = (*kratosGormLogger)(nil)
+)
+
+// traceGormLogger prefixes logged SQL with the trace and span ids of the context passed to
+// gorm, as a comment, so text logs of slow queries can be tied to traces.
+type traceGormLogger struct {
+	glog.Interface
+}
+
+func (l *traceGormLogger) LogMode(level glog.LogLevel) glog.Interface {
+	return &traceGormLogger{Interface: l.Interface.LogMode(level)}
+}
+
+// ParamsFilter keeps the parameter hiding of the wrapped logger.
+func (l *traceGormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if f, ok := l.Interface.(gorm.ParamsFilter); ok {
+		return f.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+func (l *traceGormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		l.Interface.Trace(ctx, begin, fc, err)
+		return
+	}
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		sql, rows := fc()
+		return fmt.Sprintf("/* trace.id=%s span.id=%s */ %s", sc.TraceID(), sc.SpanID(), sql), rows
+	}, err)
+}
+
+// kratosGormLogger writes gorm logs as structured lines of a kratos logger, with the
+// trace and span ids of the context passed to gorm.
+type kratosGormLogger struct {
+	log    log.Logger
+	config glog.Config
+}
+
+// NewKratosGormLogger returns a gorm logger writing to logger, with the defaults and
+// options of NewGormLogger. Queries are logged with the keys sql, rows, elapsed_ms,
+// trace.id and span.id, slow queries at warn and failed ones at error.
+func NewKratosGormLogger(logger log.Logger, logOpt *LogOption, opts ...database.GormLoggerOption) glog.Interface {
+	c := newDefaultConfig()
+	c.LogLevel = getGormLogLevel(logOpt.GetLevel())
+	for _, opt := range opts {
+		opt(c)
+	}
+	l := &kratosGormLogger{log: log.With(logger, ModuleKey, "gorm"), config: *c}
+	redactor, err := NewRedactor(logOpt.GetRedact())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gorm log redaction disabled -> %s\n", err.Error())
+	}
+	return redactor.Gorm(l)
+}
+
+func (l *kratosGormLogger) LogMode(level glog.LogLevel) glog.Interface {
+	next := *l
+	next.config.LogLevel = level
+	return &next
+}
+
+func (l *kratosGormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= glog.Info {
+		l.print(ctx, log.LevelInfo, log.DefaultMessageKey, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *kratosGormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= glog.Warn {
+		l.print(ctx, log.LevelWarn, log.DefaultMessageKey, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *kratosGormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= glog.Error {
+		l.print(ctx, log.LevelError, log.DefaultMessageKey, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *kratosGormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.config.LogLevel <= glog.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.config.LogLevel >= glog.Error && (!errors.Is(err, gorm.ErrRecordNotFound) || !l.config.IgnoreRecordNotFoundError):
+		sql, rows := fc()
+		l.print(ctx, log.LevelError, log.DefaultMessageKey, "query failed", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds(), "error", err.Error())
+	case l.config.SlowThreshold != 0 && elapsed > l.config.SlowThreshold && l.config.LogLevel >= glog.Warn:
+		sql, rows := fc()
+		l.print(ctx, log.LevelWarn, log.DefaultMessageKey, "slow query", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds(), "threshold_ms", l.config.SlowThreshold.Milliseconds())
+	case l.config.LogLevel >= glog.Info:
+		sql, rows := fc()
+		l.print(ctx, log.LevelInfo, log.DefaultMessageKey, "query", "sql", sql, "rows", rows, "elapsed_ms", elapsed.Milliseconds())
+	}
+}
+
+// ParamsFilter hides the parameters of logged SQL with ParameterizedQueries, like gorm's logger.
+func (l *kratosGormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.config.ParameterizedQueries {
+		return sql, nil
+	}
+	return sql, params
+}
+
+func (l *kratosGormLogger) print(ctx context.Context, level log.Level, keyvals ...interface{}) {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		keyvals = append(keyvals, "trace.id", sc.TraceID().String(), "span.id", sc.SpanID().String())
+	}
+	_ = l.log.Log(level, keyvals...)
+}
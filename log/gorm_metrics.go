@@ -0,0 +1,118 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gorm"
+	glog "gorm.io/gorm/logger"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	_ glog.Interface = (*metricsGormLogger)(nil)
+)
+
+var sqlTable = regexp.MustCompile("(?i)\\b(?:from|into|update|join)\\s+[`\"]?([\\w.]+)[`\"]?")
+
+// metricsGormLogger records query metrics before delegating to the wrapped gorm logger.
+type metricsGormLogger struct {
+	glog.Interface
+	slowThreshold time.Duration
+	duration      metric.Float64Histogram
+	slow          metric.Int64Counter
+	errors        metric.Int64Counter
+}
+
+// WithGormMetrics wraps a gorm logger, e.g. from NewGormLogger, to also record
+// db.query.duration (seconds), db.query.slow and db.query.errors. Slow and failed queries
+// are labelled by operation and table. Queries slower than slowThreshold count as slow,
+// 500ms when 0. A nil mp uses the global meter provider. It is a wrapper rather than a
+// GormLoggerOption since those only edit the gorm logger config.
+func WithGormMetrics(logger glog.Interface, mp metric.MeterProvider, slowThreshold time.Duration) glog.Interface {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	if slowThreshold <= 0 {
+		slowThreshold = newDefaultConfig().SlowThreshold
+	}
+	meter := mp.Meter("github.com/cocosip/zero/log")
+	// instrument errors leave no-op instruments, metrics must not break queries
+	duration, _ := meter.Float64Histogram("db.query.duration", metric.WithUnit("s"), metric.WithDescription("Duration of gorm queries"))
+	slow, _ := meter.Int64Counter("db.query.slow", metric.WithDescription("Gorm queries slower than the slow threshold"))
+	errs, _ := meter.Int64Counter("db.query.errors", metric.WithDescription("Failed gorm queries"))
+	return &metricsGormLogger{
+		Interface:     logger,
+		slowThreshold: slowThreshold,
+		duration:      duration,
+		slow:          slow,
+		errors:        errs,
+	}
+}
+
+func (l *metricsGormLogger) LogMode(level glog.LogLevel) glog.Interface {
+	next := *l
+	next.Interface = l.Interface.LogMode(level)
+	return &next
+}
+
+// ParamsFilter keeps the parameter hiding of the wrapped logger.
+func (l *metricsGormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if f, ok := l.Interface.(gorm.ParamsFilter); ok {
+		return f.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+// Trace reads the SQL for the operation and table labels only for slow and failed
+// queries; others are recorded without labels and fc reaches the wrapped logger untouched.
+func (l *metricsGormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := elapsed > l.slowThreshold
+	if !failed && !slow {
+		if l.duration != nil {
+			l.duration.Record(ctx, elapsed.Seconds())
+		}
+		l.Interface.Trace(ctx, begin, fc, err)
+		return
+	}
+	sql, rows := fc()
+	attrs := metric.WithAttributes(
+		attribute.String("operation", sqlOperation(sql)),
+		attribute.String("table", sqlTableName(sql)),
+	)
+	if l.duration != nil {
+		l.duration.Record(ctx, elapsed.Seconds(), attrs)
+	}
+	if l.slow != nil && slow {
+		l.slow.Add(ctx, 1, attrs)
+	}
+	if l.errors != nil && failed {
+		l.errors.Add(ctx, 1, attrs)
+	}
+	l.Interface.Trace(ctx, begin, func() (string, int64) { return sql, rows }, err)
+}
+
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	for _, field := range fields {
+		// skip leading comments like the trace prefix
+		if strings.HasPrefix(field, "/*") || strings.HasSuffix(field, "*/") || strings.Contains(field, "=") {
+			continue
+		}
+		return strings.ToUpper(field)
+	}
+	return "UNKNOWN"
+}
+
+func sqlTableName(sql string) string {
+	if m := sqlTable.FindStringSubmatch(sql); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/metric/noop"
+	glog "gorm.io/gorm/logger"
+	"testing"
+	"time"
+)
+
+func TestGormMetricsReadsSQLOnlyWhenNeeded(t *testing.T) {
+	logger := WithGormMetrics(glog.Discard, noop.NewMeterProvider(), time.Second)
+	tests := []struct {
+		name  string
+		begin time.Time
+		err   error
+		calls int
+	}{
+		{"fast", time.Now(), nil, 0},
+		{"slow", time.Now().Add(-2 * time.Second), nil, 1},
+		{"failed", time.Now(), errors.New("boom"), 1},
+	}
+	for _, tt := range tests {
+		calls := 0
+		logger.Trace(context.Background(), tt.begin, func() (string, int64) {
+			calls++
+			return "SELECT * FROM users", 1
+		}, tt.err)
+		if calls != tt.calls {
+			t.Fatalf("%s: fc called %d times, want %d", tt.name, calls, tt.calls)
+		}
+	}
+}
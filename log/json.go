@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"io"
+	"sync"
+)
+
+var (
+	_ log.Logger = (*JSONLogger)(nil)
+)
+
+// JSONLogger writes one JSON object per line, keys in logging order, so lines are
+// ingestible by Loki or Elasticsearch without parsing rules.
+type JSONLogger struct {
+	w    io.Writer
+	pool *sync.Pool
+	m    *sync.Mutex
+}
+
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{
+		w: w,
+		pool: &sync.Pool{
+			New: func() interface{} { return new(bytes.Buffer) },
+		},
+		m: &sync.Mutex{},
+	}
+}
+
+func (l *JSONLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	buf := l.pool.Get().(*bytes.Buffer)
+	defer l.pool.Put(buf)
+	buf.Reset()
+	buf.WriteString(`{"level":`)
+	writeJSONValue(buf, level.String())
+	for i := 0; i < len(keyvals); i += 2 {
+		buf.WriteByte(',')
+		writeJSONValue(buf, fmt.Sprint(keyvals[i]))
+		buf.WriteByte(':')
+		writeJSONValue(buf, keyvals[i+1])
+	}
+	buf.WriteString("}\n")
+	l.m.Lock()
+	defer l.m.Unlock()
+	_, err := l.w.Write(buf.Bytes())
+	return err
+}
+
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	switch val := v.(type) {
+	case error:
+		v = val.Error()
+	case fmt.Stringer:
+		v = val.String()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(v))
+	}
+	buf.Write(data)
+}
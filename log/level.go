@@ -0,0 +1,90 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultLevelController holds the level of loggers built by NewFromConfig, and by
+// NewLogHelper without a level.
+var DefaultLevelController = NewLevelController(log.LevelInfo)
+
+// LevelController holds a minimum level that can change while the service runs.
+type LevelController struct {
+	level *atomic.Int32
+}
+
+func NewLevelController(level log.Level) *LevelController {
+	c := &LevelController{level: &atomic.Int32{}}
+	c.SetLevel(level)
+	return c
+}
+
+func (c *LevelController) SetLevel(level log.Level) {
+	c.level.Store(int32(level))
+}
+
+func (c *LevelController) GetLevel() log.Level {
+	return log.Level(c.level.Load())
+}
+
+// Enabled reports whether lines of level pass the controller.
+func (c *LevelController) Enabled(level log.Level) bool {
+	return level >= c.GetLevel()
+}
+
+type levelBody struct {
+	Level string `json:"level"`
+}
+
+// Handler serves the level: GET returns it, PUT sets it from a JSON body like
+// {"level":"debug"} or a level query parameter. Mount it on an internal port,
+// e.g. srv.Handle("/log/level", log.DefaultLevelController.Handler()).
+func (c *LevelController) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			level := req.URL.Query().Get("level")
+			if level == "" {
+				var body levelBody
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					writeLevelError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+					return
+				}
+				level = body.Level
+			}
+			parsed, err := parseLevel(level)
+			if err != nil {
+				writeLevelError(w, http.StatusBadRequest, err)
+				return
+			}
+			c.SetLevel(parsed)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeLevelError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(levelBody{Level: c.GetLevel().String()})
+	})
+}
+
+// parseLevel is log.ParseLevel rejecting unknown levels instead of defaulting to info.
+func parseLevel(s string) (log.Level, error) {
+	level := log.ParseLevel(s)
+	if level.String() != strings.ToUpper(s) {
+		return level, fmt.Errorf("unknown level %q", s)
+	}
+	return level, nil
+}
+
+func writeLevelError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,40 @@
+package log
+
+import (
+	"github.com/go-kratos/kratos/v2/log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	c := NewLevelController(log.LevelInfo)
+	tests := []struct {
+		method string
+		target string
+		body   string
+		status int
+		level  log.Level
+	}{
+		{http.MethodGet, "/log/level", "", http.StatusOK, log.LevelInfo},
+		{http.MethodPut, "/log/level?level=debug", "", http.StatusOK, log.LevelDebug},
+		{http.MethodPut, "/log/level", `{"level":"warn"}`, http.StatusOK, log.LevelWarn},
+		{http.MethodPut, "/log/level?level=verbose", "", http.StatusBadRequest, log.LevelWarn},
+		{http.MethodPut, "/log/level", `{`, http.StatusBadRequest, log.LevelWarn},
+		{http.MethodPost, "/log/level?level=error", "", http.StatusMethodNotAllowed, log.LevelWarn},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		c.Handler().ServeHTTP(rec, httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body)))
+		if rec.Code != tt.status {
+			t.Errorf("%s %s: status %d, want %d", tt.method, tt.target, rec.Code, tt.status)
+		}
+		if got := c.GetLevel(); got != tt.level {
+			t.Errorf("%s %s: level %s, want %s", tt.method, tt.target, got, tt.level)
+		}
+	}
+	if c.Enabled(log.LevelInfo) || !c.Enabled(log.LevelError) {
+		t.Error("Enabled does not follow the level")
+	}
+}
@@ -1,26 +1,113 @@
 package log
 
 import (
+	"fmt"
 	"github.com/cocosip/utils/database"
 	ulog "github.com/cocosip/utils/log"
 	"github.com/go-kratos/kratos/v2/log"
 	glog "gorm.io/gorm/logger"
 	"io"
 	stdlog "log"
+	"os"
 	"time"
 )
 
+const (
+	BackendStd = "std"
+	BackendZap = "zap"
+
+	FormatText    = "text"
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// NewLogHelper returns a helper filtering by the level of opt. Without a level it follows
+// DefaultLevelController, so the level can be changed at runtime through its Handler.
+// Lines with a ModuleKey listed in the modules of opt are filtered by that level instead.
 func NewLogHelper(logger log.Logger, opt *LogOption) *log.Helper {
-	level := log.ParseLevel(opt.GetLevel())
-	helper := log.NewHelper(
-		log.NewFilter(logger,
-			log.FilterLevel(level),
-			log.FilterKey(opt.GetFilterKeys()...),
-		))
-	return helper
+	controller := DefaultLevelController
+	if opt.GetLevel() != "" {
+		controller = NewLevelController(log.ParseLevel(opt.GetLevel()))
+	}
+	return log.NewHelper(newFilteredLogger(logger, opt, controller))
+}
+
+// newFilteredLogger applies the module levels, redaction, dedupe, sampling and filter keys
+// of opt; lines without a module level are filtered by controller.
+func newFilteredLogger(logger log.Logger, opt *LogOption, controller *LevelController) log.Logger {
+	redactor, err := NewRedactor(opt.GetRedact())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "log redaction disabled -> %s\n", err.Error())
+	}
+	logger = redactor.Logger(logger)
+	logger = NewDedupeLogger(logger, time.Duration(opt.GetDedupeWindow())*time.Second)
+	logger = NewSamplingLogger(logger, opt.GetSampling())
+	logger = log.NewFilter(logger,
+		// the module levels decide, the filter level must not drop debug lines
+		log.FilterLevel(log.LevelDebug),
+		log.FilterKey(opt.GetFilterKeys()...),
+	)
+	return newModuleLevelLogger(logger, opt.GetModules(), controller)
 }
 
+// ModuleKey is the key naming the subsystem of a log line, see LogOption.modules.
+const ModuleKey = "module"
+
+// moduleLevelLogger drops lines below the level of their module, or of its controller
+// without an override. Unlike a log.FilterFunc, which log.Filter
+// runs on prefix and line key/values separately, it sees the prefix added by log.With and
+// the line together, so it must wrap every other filter.
+type moduleLevelLogger struct {
+	logger     log.Logger
+	levels     map[string]log.Level
+	controller *LevelController
+}
+
+func newModuleLevelLogger(logger log.Logger, modules map[string]string, controller *LevelController) *moduleLevelLogger {
+	levels := make(map[string]log.Level, len(modules))
+	for module, level := range modules {
+		levels[module] = log.ParseLevel(level)
+	}
+	return &moduleLevelLogger{logger: logger, levels: levels, controller: controller}
+}
+
+func (l *moduleLevelLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if !l.enabled(level, keyvals) {
+		return nil
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+// enabled resolves the module of a line from its last ModuleKey, so a module given
+// with the line overrides the one of its prefix.
+func (l *moduleLevelLogger) enabled(level log.Level, keyvals []interface{}) bool {
+	var (
+		min      log.Level
+		override bool
+	)
+	if len(l.levels) > 0 {
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			if keyvals[i] == ModuleKey {
+				min, override = l.levels[fmt.Sprint(keyvals[i+1])]
+			}
+		}
+	}
+	if override {
+		return level >= min
+	}
+	return l.controller.Enabled(level)
+}
+
+// NewFileLoggerWithOption returns a rotating file writer, rotating by size or, with a
+// rotation policy, by time; see NewTimeRotatingWriter.
 func NewFileLoggerWithOption(filename string, opt *LogOption) io.Writer {
+	if opt.GetFileOption().GetRotation() != "" {
+		w, err := NewTimeRotatingWriter(filename, opt.GetFileOption())
+		if err == nil {
+			return w
+		}
+		fmt.Fprintf(os.Stderr, "time rotating log file <%s> error, rotating by size -> %s\n", filename, err.Error())
+	}
 	return ulog.NewFileLogger(
 		ulog.WithFilename(filename),
 		ulog.WithMaxSize(int(opt.GetFileOption().MaxSize)),
@@ -32,9 +119,31 @@ func NewFileLoggerWithOption(filename string, opt *LogOption) io.Writer {
 	)
 }
 
+// NewLogger returns a std logger tagging lines with the service and trace. Nil traceId
+// and spanId default to the TraceID and SpanID valuers, correlating every line
+// logged with a context carrying a span.
 func NewLogger(w io.Writer, id, name, version string, traceId, spanId interface{}) log.Logger {
-	logger := log.With(
-		log.NewStdLogger(w),
+	return withServiceKeys(log.NewStdLogger(w), id, name, version, traceId, spanId)
+}
+
+// NewLoggerWithOption is NewLogger with the backend and format of opt.
+func NewLoggerWithOption(w io.Writer, opt *LogOption, id, name, version string, traceId, spanId interface{}) (log.Logger, error) {
+	logger, err := NewBackendLogger(w, opt)
+	if err != nil {
+		return nil, err
+	}
+	return withServiceKeys(logger, id, name, version, traceId, spanId), nil
+}
+
+func withServiceKeys(logger log.Logger, id, name, version string, traceId, spanId interface{}) log.Logger {
+	if traceId == nil {
+		traceId = TraceID()
+	}
+	if spanId == nil {
+		spanId = SpanID()
+	}
+	logger = log.With(
+		logger,
 		"ts", log.DefaultTimestamp,
 		"caller", log.DefaultCaller,
 		"service.id", id,
@@ -42,10 +151,36 @@ func NewLogger(w io.Writer, id, name, version string, traceId, spanId interface{
 		"service.version", version,
 		"trace.id", traceId,
 		"span.id", spanId,
+		TenantIDKey, TenantID(),
+		UserIDKey, UserID(),
+		RequestIDKey, RequestID(),
 	)
 	return logger
 }
 
+// NewBackendLogger returns a logger writing to w with the backend and format of opt.
+func NewBackendLogger(w io.Writer, opt *LogOption) (log.Logger, error) {
+	switch opt.GetFormat() {
+	case "", FormatText, FormatJSON, FormatConsole:
+	default:
+		return nil, fmt.Errorf("unknown log format %q", opt.GetFormat())
+	}
+	switch opt.GetBackend() {
+	case "", BackendStd:
+		switch opt.GetFormat() {
+		case FormatJSON:
+			return NewJSONLogger(w), nil
+		case FormatConsole:
+			return NewConsoleLogger(w), nil
+		}
+		return log.NewStdLogger(w), nil
+	case BackendZap:
+		return NewZapWriterLogger(w, opt.GetLevel(), opt.GetFormat()), nil
+	default:
+		return nil, fmt.Errorf("unknown log backend %q", opt.GetBackend())
+	}
+}
+
 func newDefaultConfig() *glog.Config {
 	c := &glog.Config{
 		SlowThreshold:             500 * time.Millisecond,
@@ -57,13 +192,19 @@ func newDefaultConfig() *glog.Config {
 	return c
 }
 
+// NewGormLogger returns a gorm logger writing text lines to w. SQL of queries whose
+// context carries a span is prefixed with the trace and span ids.
 func NewGormLogger(w io.Writer, logOpt *LogOption, opts ...database.GormLoggerOption) glog.Interface {
 	c := newDefaultConfig()
 	c.LogLevel = getGormLogLevel(logOpt.GetLevel())
 	for _, opt := range opts {
 		opt(c)
 	}
-	return glog.New(stdlog.New(w, "", 0), *c)
+	redactor, err := NewRedactor(logOpt.GetRedact())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gorm log redaction disabled -> %s\n", err.Error())
+	}
+	return redactor.Gorm(&traceGormLogger{Interface: glog.New(stdlog.New(w, "", 0), *c)})
 }
 
 func getGormLogLevel(s string) glog.LogLevel {
@@ -0,0 +1,118 @@
+package log
+
+import (
+	"github.com/go-kratos/kratos/v2/log"
+	"slices"
+	"testing"
+)
+
+type recordLogger struct {
+	lines [][]interface{}
+}
+
+func (l *recordLogger) Log(_ log.Level, keyvals ...interface{}) error {
+	l.lines = append(l.lines, keyvals)
+	return nil
+}
+
+func (l *recordLogger) messages() []string {
+	var msgs []string
+	for _, kv := range l.lines {
+		for i := 0; i+1 < len(kv); i += 2 {
+			if kv[i] == log.DefaultMessageKey {
+				msgs = append(msgs, kv[i+1].(string))
+			}
+		}
+	}
+	return msgs
+}
+
+func withLevel(t *testing.T, level log.Level) {
+	prev := DefaultLevelController.GetLevel()
+	DefaultLevelController.SetLevel(level)
+	t.Cleanup(func() { DefaultLevelController.SetLevel(prev) })
+}
+
+func TestModuleLevels(t *testing.T) {
+	withLevel(t, log.LevelWarn)
+	opt := &LogOption{
+		Modules: map[string]string{"registry": "debug", "gorm": "error"},
+		Redact:  &LogOption_RedactOption{Keys: []string{"password"}},
+	}
+	tests := []struct {
+		name   string
+		prefix []interface{}
+		level  log.Level
+		line   []interface{}
+		want   bool
+	}{
+		{"default below", nil, log.LevelInfo, nil, false},
+		{"default at", nil, log.LevelWarn, nil, true},
+		{"verbose module in line", nil, log.LevelDebug, []interface{}{ModuleKey, "registry"}, true},
+		{"verbose module in prefix", []interface{}{ModuleKey, "registry"}, log.LevelDebug, nil, true},
+		{"quiet module in prefix", []interface{}{ModuleKey, "gorm"}, log.LevelWarn, nil, false},
+		{"quiet module at level", []interface{}{ModuleKey, "gorm"}, log.LevelError, nil, true},
+		{"line module overrides prefix", []interface{}{ModuleKey, "registry"}, log.LevelDebug, []interface{}{ModuleKey, "gorm"}, false},
+		{"unknown module", []interface{}{ModuleKey, "http"}, log.LevelInfo, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recordLogger{}
+			// loggers carry the service keys as prefix below the filters
+			logger := newFilteredLogger(log.With(rec, "service.name", "test"), opt, DefaultLevelController)
+			if tt.prefix != nil {
+				logger = log.With(logger, tt.prefix...)
+			}
+			_ = logger.Log(tt.level, append(tt.line, log.DefaultMessageKey, "hello")...)
+			if got := len(rec.lines) == 1; got != tt.want {
+				t.Fatalf("logged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogHelperFollowsController(t *testing.T) {
+	withLevel(t, log.LevelInfo)
+	rec := &recordLogger{}
+	helper := NewLogHelper(rec, &LogOption{})
+	helper.Debug("debug")
+	helper.Info("info")
+	DefaultLevelController.SetLevel(log.LevelDebug)
+	helper.Debug("debug after")
+	if want := []string{"info", "debug after"}; !slices.Equal(rec.messages(), want) {
+		t.Fatalf("messages %q, want %q", rec.messages(), want)
+	}
+}
+
+func TestLogHelperLevel(t *testing.T) {
+	withLevel(t, log.LevelInfo)
+	rec := &recordLogger{}
+	helper := NewLogHelper(rec, &LogOption{Level: "error", Modules: map[string]string{"registry": "debug"}})
+	if got := DefaultLevelController.GetLevel(); got != log.LevelInfo {
+		t.Fatalf("NewLogHelper set the controller to %s", got)
+	}
+	helper.Warn("warn")
+	helper.Error("error")
+	helper.Log(log.LevelDebug, ModuleKey, "registry", log.DefaultMessageKey, "registry debug")
+	DefaultLevelController.SetLevel(log.LevelDebug)
+	helper.Info("info after")
+	if want := []string{"error", "registry debug"}; !slices.Equal(rec.messages(), want) {
+		t.Fatalf("messages %q, want %q", rec.messages(), want)
+	}
+}
+
+func TestModuleLevelsBeforeFilterKeys(t *testing.T) {
+	withLevel(t, log.LevelWarn)
+	rec := &recordLogger{}
+	logger := log.With(newFilteredLogger(rec, &LogOption{
+		Modules:    map[string]string{"registry": "debug"},
+		FilterKeys: []string{"token"},
+	}, DefaultLevelController), ModuleKey, "registry")
+	_ = logger.Log(log.LevelDebug, "token", "secret", log.DefaultMessageKey, "hello")
+	if len(rec.lines) != 1 {
+		t.Fatalf("logged %d lines, want 1", len(rec.lines))
+	}
+	if i := slices.Index(rec.lines[0], "token"); i < 0 || rec.lines[0][i+1] == "secret" {
+		t.Fatalf("filter key not applied: %v", rec.lines[0])
+	}
+}
@@ -0,0 +1,187 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/transport"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ io.WriteCloser   = (*LokiWriter)(nil)
+	_ transport.Server = (*LokiWriter)(nil)
+)
+
+const (
+	defaultLokiBatchSize     = 500
+	defaultLokiFlushInterval = 5 * time.Second
+	lokiPushPath             = "/loki/api/v1/push"
+)
+
+// LokiWriter batches written lines and pushes them to the Grafana Loki HTTP API. It
+// flushes on its interval and whenever a batch is full while started as a kratos server,
+// and on Stop/Close. Push failures are reported on stderr, since the writer may be the
+// sink of the logger itself, and the batch is dropped.
+type LokiWriter struct {
+	opt      *LogOption_LokiOption
+	labels   map[string]string
+	client   *http.Client
+	lines    [][2]string
+	dropped  int
+	flushc   chan struct{}
+	stop     chan struct{}
+	once     *sync.Once
+	m        *sync.Mutex
+	pushLock *sync.Mutex
+}
+
+// NewLokiWriter returns a writer labelling its stream with service_id, service_name,
+// service_version and the labels of opt.
+func NewLokiWriter(opt *LogOption_LokiOption, id, name, version string) *LokiWriter {
+	labels := map[string]string{
+		"service_id":      id,
+		"service_name":    name,
+		"service_version": version,
+	}
+	for k, v := range opt.GetLabels() {
+		labels[k] = v
+	}
+	return &LokiWriter{
+		opt:      opt,
+		labels:   labels,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		flushc:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		once:     &sync.Once{},
+		m:        &sync.Mutex{},
+		pushLock: &sync.Mutex{},
+	}
+}
+
+func (w *LokiWriter) Write(p []byte) (int, error) {
+	ts := strconv.FormatInt(time.Now().UnixNano(), 10)
+	w.m.Lock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		w.lines = append(w.lines, [2]string{ts, line})
+	}
+	// without a running flusher the buffer would grow unbounded, keep the newest lines
+	if limit := 10 * w.batchSize(); len(w.lines) > limit {
+		w.dropped += len(w.lines) - limit
+		w.lines = w.lines[len(w.lines)-limit:]
+	}
+	full := len(w.lines) >= w.batchSize()
+	w.m.Unlock()
+	if full {
+		select {
+		case w.flushc <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+func (w *LokiWriter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.flushInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.stop:
+			return nil
+		case <-ticker.C:
+		case <-w.flushc:
+		}
+		if err := w.Flush(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "push logs to loki error -> %s\n", err.Error())
+		}
+	}
+}
+
+func (w *LokiWriter) Stop(ctx context.Context) error {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+	return w.Flush(ctx)
+}
+
+func (w *LokiWriter) Close() error {
+	return w.Stop(context.Background())
+}
+
+// Flush pushes the buffered lines in batches of batch_size.
+func (w *LokiWriter) Flush(ctx context.Context) error {
+	w.pushLock.Lock()
+	defer w.pushLock.Unlock()
+	w.m.Lock()
+	lines, dropped := w.lines, w.dropped
+	w.lines, w.dropped = nil, 0
+	w.m.Unlock()
+	if dropped > 0 {
+		fmt.Fprintf(os.Stderr, "loki writer dropped %d lines\n", dropped)
+	}
+	for len(lines) > 0 {
+		n := min(len(lines), w.batchSize())
+		if err := w.push(ctx, lines[:n]); err != nil {
+			return err
+		}
+		lines = lines[n:]
+	}
+	return nil
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+func (w *LokiWriter) push(ctx context.Context, lines [][2]string) error {
+	body, err := json.Marshal(lokiPush{Streams: []lokiStream{{Stream: w.labels, Values: lines}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(w.opt.GetEndpoint(), "/")+lokiPushPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tenant := w.opt.GetTenant(); tenant != "" {
+		req.Header.Set("X-Scope-OrgID", tenant)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("loki push: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func (w *LokiWriter) batchSize() int {
+	if size := int(w.opt.GetBatchSize()); size > 0 {
+		return size
+	}
+	return defaultLokiBatchSize
+}
+
+func (w *LokiWriter) flushInterval() time.Duration {
+	if interval := w.opt.GetFlushInterval(); interval > 0 {
+		return time.Duration(interval) * time.Second
+	}
+	return defaultLokiFlushInterval
+}
@@ -0,0 +1,177 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	glog "gorm.io/gorm/logger"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	_ log.Logger     = (*redactLogger)(nil)
+	_ glog.Interface = (*redactGormLogger)(nil)
+)
+
+const defaultRedactMask = "***"
+
+// Redactor masks sensitive values: the values of keys containing one of its key
+// patterns, case-insensitively, and every match of its value patterns, e.g. card numbers.
+type Redactor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+	sqlKeys  *regexp.Regexp
+	mask     string
+}
+
+// NewRedactor builds a redactor from opt; it returns nil without keys or patterns.
+func NewRedactor(opt *LogOption_RedactOption) (*Redactor, error) {
+	if len(opt.GetKeys()) == 0 && len(opt.GetPatterns()) == 0 {
+		return nil, nil
+	}
+	r := &Redactor{mask: opt.GetMask()}
+	if r.mask == "" {
+		r.mask = defaultRedactMask
+	}
+	quoted := make([]string, 0, len(opt.GetKeys()))
+	for _, key := range opt.GetKeys() {
+		r.keys = append(r.keys, strings.ToLower(key))
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	if len(quoted) > 0 {
+		// column = 'value' or column = "value" of a matching column in SQL
+		r.sqlKeys = regexp.MustCompile(`(?i)(\w*(?:` + strings.Join(quoted, "|") + `)\w*["` + "`" + `]?\s*=\s*)('[^']*'|"[^"]*")`)
+	}
+	for _, pattern := range opt.GetPatterns() {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Logger wraps logger so key/values are redacted before they are written. A nil
+// redactor returns logger.
+func (r *Redactor) Logger(logger log.Logger) log.Logger {
+	if r == nil {
+		return logger
+	}
+	return &redactLogger{logger: logger, r: r}
+}
+
+// Gorm wraps a gorm logger so logged SQL is redacted. A nil redactor returns logger.
+func (r *Redactor) Gorm(logger glog.Interface) glog.Interface {
+	if r == nil {
+		return logger
+	}
+	return &redactGormLogger{Interface: logger, r: r}
+}
+
+// String masks the pattern matches in s.
+func (r *Redactor) String(s string) string {
+	for _, re := range r.patterns {
+		s = re.ReplaceAllString(s, r.mask)
+	}
+	return s
+}
+
+// SQL masks the string literals assigned to matching columns and the pattern matches in sql.
+func (r *Redactor) SQL(sql string) string {
+	if r.sqlKeys != nil {
+		sql = r.sqlKeys.ReplaceAllString(sql, "${1}'"+r.mask+"'")
+	}
+	return r.String(sql)
+}
+
+func (r *Redactor) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+type redactLogger struct {
+	logger log.Logger
+	r      *Redactor
+}
+
+func (l *redactLogger) Log(level log.Level, keyvals ...interface{}) error {
+	redacted := make([]interface{}, len(keyvals))
+	copy(redacted, keyvals)
+	for i := 0; i+1 < len(redacted); i += 2 {
+		key := fmt.Sprint(redacted[i])
+		switch v := redacted[i+1].(type) {
+		case log.Valuer:
+			// resolved by an inner log.With logger, only key redaction applies
+			if l.r.sensitive(key) {
+				redacted[i+1] = l.r.mask
+			}
+			continue
+		case string:
+			redacted[i+1] = l.r.String(v)
+		case error:
+			redacted[i+1] = l.r.String(v.Error())
+		case fmt.Stringer:
+			redacted[i+1] = l.r.String(v.String())
+		}
+		if l.r.sensitive(key) {
+			redacted[i+1] = l.r.mask
+		}
+	}
+	return l.logger.Log(level, redacted...)
+}
+
+type redactGormLogger struct {
+	glog.Interface
+	r *Redactor
+}
+
+func (l *redactGormLogger) LogMode(level glog.LogLevel) glog.Interface {
+	return &redactGormLogger{Interface: l.Interface.LogMode(level), r: l.r}
+}
+
+func (l *redactGormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	l.Interface.Info(ctx, l.r.String(msg), l.data(data)...)
+}
+
+func (l *redactGormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	l.Interface.Warn(ctx, l.r.String(msg), l.data(data)...)
+}
+
+func (l *redactGormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	l.Interface.Error(ctx, l.r.String(msg), l.data(data)...)
+}
+
+func (l *redactGormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		sql, rows := fc()
+		return l.r.SQL(sql), rows
+	}, err)
+}
+
+// ParamsFilter keeps the parameter hiding of the wrapped logger.
+func (l *redactGormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if f, ok := l.Interface.(gorm.ParamsFilter); ok {
+		return f.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, params
+}
+
+func (l *redactGormLogger) data(data []interface{}) []interface{} {
+	redacted := make([]interface{}, len(data))
+	for i, v := range data {
+		if s, ok := v.(string); ok {
+			v = l.r.String(s)
+		}
+		redacted[i] = v
+	}
+	return redacted
+}
@@ -0,0 +1,193 @@
+package log
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ io.WriteCloser = (*TimeRotatingWriter)(nil)
+)
+
+const (
+	RotationDaily  = "daily"
+	RotationHourly = "hourly"
+)
+
+// TimeRotatingWriter writes to one file per day or hour, named after the period, e.g.
+// app-2024-05-01.log for app.log. Files older than max_age days, or beyond the newest
+// max_backups, are removed after each rotation.
+type TimeRotatingWriter struct {
+	dir, base, ext string
+	layout         string
+	opt            *LogOption_LogFileOption
+	period         string
+	file           *os.File
+	m              *sync.Mutex
+}
+
+func NewTimeRotatingWriter(filename string, opt *LogOption_LogFileOption) (*TimeRotatingWriter, error) {
+	layout := "2006-01-02"
+	switch opt.GetRotation() {
+	case RotationDaily:
+	case RotationHourly:
+		layout = "2006-01-02T15"
+	default:
+		return nil, fmt.Errorf("unknown log rotation %q", opt.GetRotation())
+	}
+	ext := filepath.Ext(filename)
+	w := &TimeRotatingWriter{
+		dir:    filepath.Dir(filename),
+		base:   strings.TrimSuffix(filepath.Base(filename), ext),
+		ext:    ext,
+		layout: layout,
+		opt:    opt,
+		m:      &sync.Mutex{},
+	}
+	if err := os.MkdirAll(w.dir, 0o755); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *TimeRotatingWriter) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if period := w.now().Format(w.layout); period != w.period || w.file == nil {
+		if err := w.rotate(period); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	if w.opt.GetStdout() {
+		_, _ = os.Stdout.Write(p)
+	}
+	return n, err
+}
+
+func (w *TimeRotatingWriter) Close() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *TimeRotatingWriter) rotate(period string) error {
+	previous := ""
+	if w.file != nil {
+		previous = w.file.Name()
+		_ = w.file.Close()
+	}
+	file, err := os.OpenFile(w.filename(period), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file, w.period = file, period
+	go cleanup(rotation{
+		dir:        w.dir,
+		base:       w.base,
+		ext:        w.ext,
+		layout:     w.layout,
+		previous:   previous,
+		current:    file.Name(),
+		compress:   w.opt.GetCompress(),
+		maxAge:     int(w.opt.GetMaxAge()),
+		maxBackups: int(w.opt.GetMaxBackups()),
+		now:        w.now(),
+	})
+	return nil
+}
+
+func (w *TimeRotatingWriter) filename(period string) string {
+	return filepath.Join(w.dir, w.base+"-"+period+w.ext)
+}
+
+// rotation is what cleanup needs of the writer, taken under its lock.
+type rotation struct {
+	dir, base, ext, layout string
+	previous, current      string
+	compress               bool
+	maxAge, maxBackups     int
+	now                    time.Time
+}
+
+// cleanup compresses the previous file when configured and applies the retention.
+func cleanup(r rotation) {
+	if r.previous != "" && r.compress {
+		if err := gzipFile(r.previous); err != nil {
+			fmt.Fprintf(os.Stderr, "compress log file <%s> error -> %s\n", r.previous, err.Error())
+		}
+	}
+	matches, err := filepath.Glob(filepath.Join(r.dir, r.base+"-*"))
+	if err != nil {
+		return
+	}
+	var files []string
+	for _, name := range matches {
+		period := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(name), r.base+"-"), ".gz"), r.ext)
+		if _, err := time.Parse(r.layout, period); err == nil && name != r.current {
+			files = append(files, name)
+		}
+	}
+	// the period layouts sort chronologically, newest first
+	slices.Sort(files)
+	slices.Reverse(files)
+	cutoff := r.now.AddDate(0, 0, -r.maxAge)
+	for i, name := range files {
+		expired := r.maxAge > 0 && modTime(name).Before(cutoff)
+		excess := r.maxBackups > 0 && i >= r.maxBackups
+		if expired || excess {
+			_ = os.Remove(name)
+		}
+	}
+}
+
+func modTime(name string) time.Time {
+	info, err := os.Stat(name)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func (w *TimeRotatingWriter) now() time.Time {
+	if w.opt.GetLocalTime() {
+		return time.Now()
+	}
+	return time.Now().UTC()
+}
+
+func gzipFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(name + ".gz")
+		return err
+	}
+	return os.Remove(name)
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ log.Logger = (*samplingLogger)(nil)
+
+// samplingLogger logs the first initial lines of each level and message within a tick,
+// then every thereafter-th, like zap's sampler. Levels not sampled pass through.
+type samplingLogger struct {
+	logger     log.Logger
+	initial    int
+	thereafter int
+	tick       time.Duration
+	levels     map[log.Level]bool
+	counts     map[string]*sampleCount
+	m          *sync.Mutex
+}
+
+type sampleCount struct {
+	since time.Time
+	n     int
+}
+
+// NewSamplingLogger wraps logger with the sampling of opt. Sampling is disabled when
+// initial is 0; tick defaults to one second and levels to debug and info.
+func NewSamplingLogger(logger log.Logger, opt *LogOption_LogSamplingOption) log.Logger {
+	if opt.GetInitial() <= 0 {
+		return logger
+	}
+	s := &samplingLogger{
+		logger:     logger,
+		initial:    int(opt.GetInitial()),
+		thereafter: int(opt.GetThereafter()),
+		tick:       time.Duration(opt.GetTick()) * time.Second,
+		levels:     make(map[log.Level]bool),
+		counts:     make(map[string]*sampleCount),
+		m:          &sync.Mutex{},
+	}
+	if s.tick <= 0 {
+		s.tick = time.Second
+	}
+	levels := opt.GetLevels()
+	if len(levels) == 0 {
+		levels = []string{"debug", "info"}
+	}
+	for _, level := range levels {
+		s.levels[log.ParseLevel(level)] = true
+	}
+	return s
+}
+
+func (s *samplingLogger) Log(level log.Level, keyvals ...interface{}) error {
+	if s.levels[level] && !s.sample(level, keyvals) {
+		return nil
+	}
+	return s.logger.Log(level, keyvals...)
+}
+
+func (s *samplingLogger) sample(level log.Level, keyvals []interface{}) bool {
+	key := level.String() + "|" + message(keyvals)
+	now := time.Now()
+	s.m.Lock()
+	defer s.m.Unlock()
+	c, ok := s.counts[key]
+	if !ok || now.Sub(c.since) >= s.tick {
+		if len(s.counts) >= maxSampleKeys {
+			s.prune(now)
+		}
+		c = &sampleCount{since: now}
+		s.counts[key] = c
+	}
+	c.n++
+	if c.n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (c.n-s.initial)%s.thereafter == 0
+}
+
+// maxSampleKeys bounds the tracked messages; expired ones are pruned beyond it.
+const maxSampleKeys = 4096
+
+func (s *samplingLogger) prune(now time.Time) {
+	for key, c := range s.counts {
+		if now.Sub(c.since) >= s.tick {
+			delete(s.counts, key)
+		}
+	}
+}
+
+// message returns the msg value of keyvals, or all of them without one.
+func message(keyvals []interface{}) string {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == log.DefaultMessageKey {
+			return fmt.Sprint(keyvals[i+1])
+		}
+	}
+	var sb strings.Builder
+	for _, kv := range keyvals {
+		fmt.Fprint(&sb, kv, " ")
+	}
+	return sb.String()
+}
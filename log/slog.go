@@ -0,0 +1,138 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"log/slog"
+	"time"
+)
+
+var (
+	_ log.Logger   = (*SlogLogger)(nil)
+	_ slog.Handler = (*slogHandler)(nil)
+)
+
+// SlogLogger is a kratos log.Logger writing to a slog.Handler.
+type SlogLogger struct {
+	h slog.Handler
+}
+
+func NewSlogLogger(h slog.Handler) *SlogLogger {
+	return &SlogLogger{h: h}
+}
+
+func (l *SlogLogger) Log(level log.Level, keyvals ...interface{}) error {
+	ctx := context.Background()
+	lvl := slogLevel(level)
+	if !l.h.Enabled(ctx, lvl) {
+		return nil
+	}
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	r := slog.NewRecord(time.Now(), lvl, "", 0)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		if key == log.DefaultMessageKey {
+			r.Message = fmt.Sprint(keyvals[i+1])
+			continue
+		}
+		r.AddAttrs(slog.Any(key, keyvals[i+1]))
+	}
+	return l.h.Handle(ctx, r)
+}
+
+// NewSlogHandler returns a slog.Handler forwarding records to logger, so libraries
+// logging with slog share the kratos pipeline: slog.New(NewSlogHandler(logger)).
+// Groups prefix attribute keys with "group.".
+func NewSlogHandler(logger log.Logger) slog.Handler {
+	return &slogHandler{logger: logger}
+}
+
+type slogHandler struct {
+	logger log.Logger
+	attrs  []interface{}
+	prefix string
+}
+
+// Enabled leaves level filtering to the kratos logger, e.g. log.NewFilter.
+func (h *slogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	keyvals := make([]interface{}, 0, len(h.attrs)+2*r.NumAttrs()+2)
+	keyvals = append(keyvals, log.DefaultMessageKey, r.Message)
+	keyvals = append(keyvals, h.attrs...)
+	r.Attrs(func(attr slog.Attr) bool {
+		keyvals = appendAttr(keyvals, h.prefix, attr)
+		return true
+	})
+	return log.WithContext(ctx, h.logger).Log(kratosLevel(r.Level), keyvals...)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = append([]interface{}{}, h.attrs...)
+	for _, attr := range attrs {
+		next.attrs = appendAttr(next.attrs, h.prefix, attr)
+	}
+	return &next
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	next := *h
+	next.prefix = h.prefix + name + "."
+	return &next
+}
+
+func appendAttr(keyvals []interface{}, prefix string, attr slog.Attr) []interface{} {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return keyvals
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			keyvals = appendAttr(keyvals, prefix, a)
+		}
+		return keyvals
+	}
+	return append(keyvals, prefix+attr.Key, attr.Value.Any())
+}
+
+func slogLevel(level log.Level) slog.Level {
+	switch level {
+	case log.LevelDebug:
+		return slog.LevelDebug
+	case log.LevelWarn:
+		return slog.LevelWarn
+	case log.LevelError:
+		return slog.LevelError
+	case log.LevelFatal:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func kratosLevel(level slog.Level) log.Level {
+	switch {
+	case level >= slog.LevelError+4:
+		return log.LevelFatal
+	case level >= slog.LevelError:
+		return log.LevelError
+	case level >= slog.LevelWarn:
+		return log.LevelWarn
+	case level >= slog.LevelInfo:
+		return log.LevelInfo
+	default:
+		return log.LevelDebug
+	}
+}
@@ -0,0 +1,124 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ io.WriteCloser = (*SyslogWriter)(nil)
+)
+
+const (
+	// syslogPriority is facility user (1) with severity informational (6).
+	syslogPriority = 1*8 + 6
+	syslogNil      = "-"
+)
+
+// SyslogWriter sends every written line as an RFC 5424 message to a syslog daemon, e.g.
+// rsyslog. Over tcp messages use octet-counting framing (RFC 6587). A failed write
+// reconnects once.
+type SyslogWriter struct {
+	network  string
+	addr     string
+	tag      string
+	hostname string
+	conn     net.Conn
+	m        *sync.Mutex
+}
+
+// NewSyslogWriter connects to addr over network, udp, tcp or unix/unixgram; tag is the
+// APP-NAME of the messages.
+func NewSyslogWriter(network, addr, tag string) (*SyslogWriter, error) {
+	hostname, _ := os.Hostname()
+	w := &SyslogWriter{
+		network:  network,
+		addr:     addr,
+		tag:      syslogField(tag, 48),
+		hostname: syslogField(hostname, 255),
+		m:        &sync.Mutex{},
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		msg := w.format(line)
+		if _, err := w.send(msg); err != nil {
+			if err = w.connect(); err != nil {
+				return 0, err
+			}
+			if _, err = w.send(msg); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (w *SyslogWriter) Close() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// format returns <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG.
+func (w *SyslogWriter) format(line string) string {
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		syslogPriority, time.Now().Format(time.RFC3339Nano), w.hostname, w.tag, os.Getpid(), syslogNil, syslogNil, line)
+}
+
+func (w *SyslogWriter) send(msg string) (int, error) {
+	if w.conn == nil {
+		return 0, fmt.Errorf("syslog %s://%s not connected", w.network, w.addr)
+	}
+	if w.network == "tcp" || w.network == "tcp4" || w.network == "tcp6" {
+		return fmt.Fprintf(w.conn, "%d %s", len(msg), msg)
+	}
+	return io.WriteString(w.conn, msg)
+}
+
+func (w *SyslogWriter) connect() error {
+	if w.conn != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	conn, err := net.DialTimeout(w.network, w.addr, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	w.conn = conn
+	return nil
+}
+
+// syslogField replaces characters not allowed in header fields and bounds the length.
+func syslogField(s string, max int) string {
+	if s == "" {
+		return syslogNil
+	}
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > max {
+		s = s[:max]
+	}
+	return s
+}
@@ -0,0 +1,154 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	_ log.Logger       = (*Tee)(nil)
+	_ transport.Server = (*Tee)(nil)
+)
+
+const (
+	OutputStdout        = "stdout"
+	OutputStderr        = "stderr"
+	OutputFile          = "file"
+	OutputSyslog        = "syslog"
+	OutputLoki          = "loki"
+	OutputElasticsearch = "elasticsearch"
+)
+
+// Tee writes every line to several outputs, each filtered by its own level. Register it
+// as a kratos server so batching outputs run and every output is flushed on shutdown.
+type Tee struct {
+	loggers []log.Logger
+	servers []transport.Server
+	closers []io.Closer
+	stop    chan struct{}
+	once    *sync.Once
+}
+
+// NewTee builds the outputs of opt tagged with the service; without outputs it writes
+// to stdout. Loki and Elasticsearch outputs use the loki and elasticsearch options of opt.
+func NewTee(opt *LogOption, id, name, version string) (*Tee, error) {
+	t := &Tee{stop: make(chan struct{}), once: &sync.Once{}}
+	outputs := opt.GetOutputs()
+	if len(outputs) == 0 {
+		outputs = []*LogOption_OutputOption{{Type: OutputStdout}}
+	}
+	for _, out := range outputs {
+		level := opt.GetLevel()
+		if out.GetLevel() != "" {
+			level = out.GetLevel()
+		}
+		logger, err := t.output(opt, out, level, id, name, version)
+		if err != nil {
+			_ = t.Stop(context.Background())
+			return nil, fmt.Errorf("log output %s: %w", out.GetType(), err)
+		}
+		t.loggers = append(t.loggers, log.NewFilter(logger, log.FilterLevel(log.ParseLevel(level))))
+	}
+	return t, nil
+}
+
+func (t *Tee) output(opt *LogOption, out *LogOption_OutputOption, level, id, name, version string) (log.Logger, error) {
+	var w io.Writer
+	switch out.GetType() {
+	case OutputStdout:
+		w = os.Stdout
+	case OutputStderr:
+		w = os.Stderr
+	case OutputFile:
+		if out.GetPath() == "" {
+			return nil, errors.New("path is required")
+		}
+		w = NewFileLoggerWithOption(out.GetPath(), opt)
+	case OutputSyslog:
+		sw, err := NewSyslogWriter(out.GetNetwork(), out.GetPath(), name)
+		if err != nil {
+			return nil, err
+		}
+		w = sw
+	case OutputLoki:
+		lw := NewLokiWriter(opt.GetLoki(), id, name, version)
+		t.servers = append(t.servers, lw)
+		w = lw
+	case OutputElasticsearch:
+		es := NewElasticsearchLogger(opt.GetElasticsearch())
+		t.servers = append(t.servers, es)
+		return es, nil
+	default:
+		return nil, fmt.Errorf("unknown output type %q", out.GetType())
+	}
+	if c, ok := w.(io.Closer); ok && w != os.Stdout && w != os.Stderr {
+		if _, isServer := w.(transport.Server); !isServer {
+			t.closers = append(t.closers, c)
+		}
+	}
+	format := opt.GetFormat()
+	if out.GetFormat() != "" {
+		format = out.GetFormat()
+	}
+	return NewBackendLogger(w, &LogOption{Backend: opt.GetBackend(), Format: format, Level: level})
+}
+
+func (t *Tee) Log(level log.Level, keyvals ...interface{}) error {
+	var errs []error
+	for _, logger := range t.loggers {
+		if err := logger.Log(level, keyvals...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start runs the batching outputs until ctx is done or Stop.
+func (t *Tee) Start(ctx context.Context) error {
+	for _, srv := range t.servers {
+		go func(srv transport.Server) {
+			if err := srv.Start(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "start log output error -> %s\n", err.Error())
+			}
+		}(srv)
+	}
+	select {
+	case <-ctx.Done():
+	case <-t.stop:
+	}
+	return nil
+}
+
+// Stop flushes and closes every output.
+func (t *Tee) Stop(ctx context.Context) error {
+	var errs []error
+	t.once.Do(func() {
+		close(t.stop)
+		for _, srv := range t.servers {
+			if err := srv.Stop(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, c := range t.closers {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// NewTeeLogger is NewTee tagged like NewLogger with the service and trace ids.
+func NewTeeLogger(opt *LogOption, id, name, version string) (log.Logger, *Tee, error) {
+	t, err := NewTee(opt, id, name, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return withServiceKeys(t, id, name, version, nil, nil), t, nil
+}
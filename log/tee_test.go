@@ -0,0 +1,39 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewTeeClosesOutputsOnError(t *testing.T) {
+	opt := &LogOption{Outputs: []*LogOption_OutputOption{
+		{Type: OutputElasticsearch},
+		{Type: OutputFile},
+	}}
+	errc := make(chan error, 1)
+	go func() {
+		_, err := NewTee(opt, "id", "name", "v1")
+		errc <- err
+	}()
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Fatal("NewTee accepted a file output without path")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("NewTee hangs closing outputs that were never started")
+	}
+}
+
+func TestElasticsearchLoggerStopWithoutStart(t *testing.T) {
+	l := NewElasticsearchLogger(nil)
+	for i := 0; i < 2; i++ {
+		if err := l.Stop(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,29 @@
+package log
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceID returns a valuer of the trace id of the active OpenTelemetry span, empty
+// without one.
+func TraceID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+			return sc.TraceID().String()
+		}
+		return ""
+	}
+}
+
+// SpanID returns a valuer of the span id of the active OpenTelemetry span, empty
+// without one.
+func SpanID() log.Valuer {
+	return func(ctx context.Context) interface{} {
+		if sc := trace.SpanContextFromContext(ctx); sc.HasSpanID() {
+			return sc.SpanID().String()
+		}
+		return ""
+	}
+}
@@ -0,0 +1,131 @@
+package log
+
+import (
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"io"
+)
+
+var (
+	_ log.Logger = (*ZapLogger)(nil)
+)
+
+// ZapLogger is a kratos log.Logger writing structured entries through zap.
+type ZapLogger struct {
+	log    *zap.Logger
+	msgKey string
+}
+
+// NewZapLogger builds a logger from cfg, e.g. zap.NewProductionConfig() for JSON or
+// zap.NewDevelopmentConfig() for console output.
+func NewZapLogger(cfg zap.Config, opts ...zap.Option) (*ZapLogger, error) {
+	// kratos reports the caller itself, the adapter frames would be reported otherwise
+	cfg.DisableCaller = true
+	zlog, err := cfg.Build(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return WrapZap(zlog), nil
+}
+
+// NewZapProductionLogger returns a JSON logger at info level.
+func NewZapProductionLogger(opts ...zap.Option) (*ZapLogger, error) {
+	return NewZapLogger(zap.NewProductionConfig(), opts...)
+}
+
+// NewZapDevelopmentLogger returns a colorless console logger at debug level.
+func NewZapDevelopmentLogger(opts ...zap.Option) (*ZapLogger, error) {
+	return NewZapLogger(zap.NewDevelopmentConfig(), opts...)
+}
+
+// NewZapWriterLogger returns a logger writing to w at level with the production encoder
+// configuration, as JSON or, for FormatText, console lines. FormatConsole uses the
+// development encoder configuration with colored levels.
+func NewZapWriterLogger(w io.Writer, level, format string) *ZapLogger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	switch format {
+	case FormatText:
+		encoder = zapcore.NewConsoleEncoder(zap.NewProductionEncoderConfig())
+	case FormatConsole:
+		cfg := zap.NewDevelopmentEncoderConfig()
+		cfg.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(cfg)
+	}
+	core := zapcore.NewCore(
+		encoder,
+		zapcore.AddSync(w),
+		zapLevel(log.ParseLevel(level)),
+	)
+	return WrapZap(zap.New(core))
+}
+
+// WrapZap adapts an existing zap logger. Fatal entries are written without exiting, so
+// the other outputs and buffered writers still get them; exiting is left to the caller.
+func WrapZap(zlog *zap.Logger) *ZapLogger {
+	return &ZapLogger{log: zlog.WithOptions(zap.WithFatalHook(continueOnFatal{})), msgKey: log.DefaultMessageKey}
+}
+
+// continueOnFatal is a fatal hook that returns. zap replaces zapcore.WriteThenNoop with
+// its exiting default.
+type continueOnFatal struct{}
+
+func (continueOnFatal) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
+func (l *ZapLogger) Log(level log.Level, keyvals ...interface{}) error {
+	lvl := zapLevel(level)
+	if !l.log.Core().Enabled(lvl) {
+		return nil
+	}
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, "KEYVALS UNPAIRED")
+	}
+	var msg string
+	fields := make([]zap.Field, 0, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		if key == l.msgKey {
+			msg = fmt.Sprint(keyvals[i+1])
+			continue
+		}
+		fields = append(fields, zap.Any(key, keyvals[i+1]))
+	}
+	if ce := l.log.Check(lvl, msg); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+// Zap returns the underlying zap logger.
+func (l *ZapLogger) Zap() *zap.Logger {
+	return l.log
+}
+
+func (l *ZapLogger) Sync() error {
+	return l.log.Sync()
+}
+
+func (l *ZapLogger) Close() error {
+	return l.Sync()
+}
+
+func zapLevel(level log.Level) zapcore.Level {
+	switch level {
+	case log.LevelDebug:
+		return zapcore.DebugLevel
+	case log.LevelInfo:
+		return zapcore.InfoLevel
+	case log.LevelWarn:
+		return zapcore.WarnLevel
+	case log.LevelError:
+		return zapcore.ErrorLevel
+	case log.LevelFatal:
+		return zapcore.FatalLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
@@ -0,0 +1,20 @@
+package log
+
+import (
+	"bytes"
+	"github.com/go-kratos/kratos/v2/log"
+	"strings"
+	"testing"
+)
+
+func TestZapFatalDoesNotExit(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewZapWriterLogger(&buf, "info", FormatJSON)
+	// the test binary exits here if the fatal hook isn't replaced
+	if err := l.Log(log.LevelFatal, log.DefaultMessageKey, "fatal"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"level":"fatal"`) {
+		t.Fatalf("fatal entry not written: %q", buf.String())
+	}
+}
@@ -0,0 +1,313 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ transport.Server = (*Pusher)(nil)
+)
+
+const (
+	defaultPushInterval   = 15 * time.Second
+	defaultPushBatchSize  = 100
+	defaultPushMaxRetries = 3
+	defaultPushBackoff    = 500 * time.Millisecond
+	textContentType       = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Pusher periodically collects metrics from an OpenTelemetry meter provider and pushes
+// them to a Prometheus Pushgateway, for hosts that can't be scraped.
+type Pusher struct {
+	opt    *MetricsOption_PushOption
+	reader *sdkmetric.ManualReader
+	client *http.Client
+	log    *log.Helper
+	stop   chan struct{}
+	once   *sync.Once
+	m      *sync.Mutex
+}
+
+func NewPusher(opt *MetricsOption_PushOption, logger log.Logger) *Pusher {
+	return &Pusher{
+		opt:    opt,
+		reader: sdkmetric.NewManualReader(),
+		client: &http.Client{Timeout: 10 * time.Second},
+		log:    log.NewHelper(logger),
+		stop:   make(chan struct{}),
+		once:   &sync.Once{},
+		m:      &sync.Mutex{},
+	}
+}
+
+// Reader returns the reader that must be registered on the meter provider whose metrics are pushed.
+func (p *Pusher) Reader() sdkmetric.Reader {
+	return p.reader
+}
+
+func (p *Pusher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.stop:
+			return nil
+		case <-ticker.C:
+			if err := p.Push(ctx); err != nil {
+				p.log.Errorf("push metrics error -> %s", err.Error())
+			}
+		}
+	}
+}
+
+func (p *Pusher) Stop(ctx context.Context) error {
+	p.once.Do(func() {
+		close(p.stop)
+	})
+	return p.Push(ctx)
+}
+
+// Push collects the current metrics and sends them in batches of at most batch_size families.
+func (p *Pusher) Push(ctx context.Context) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	rm := metricdata.ResourceMetrics{}
+	if err := p.reader.Collect(ctx, &rm); err != nil {
+		return err
+	}
+	families := encodeFamilies(&rm)
+	if len(families) == 0 {
+		return nil
+	}
+	target, err := p.url()
+	if err != nil {
+		return err
+	}
+	size := p.batchSize()
+	for start := 0; start < len(families); start += size {
+		end := min(start+size, len(families))
+		body := []byte(strings.Join(families[start:end], ""))
+		if err = p.send(ctx, target, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pusher) send(ctx context.Context, target string, body []byte) error {
+	var err error
+	backoff := defaultPushBackoff
+	for attempt := 0; attempt <= p.maxRetries(); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		var retry bool
+		if retry, err = p.post(ctx, target, body); err == nil || !retry {
+			return err
+		}
+		p.log.Warnf("push metrics attempt %d failed -> %s", attempt+1, err.Error())
+	}
+	return err
+}
+
+func (p *Pusher) post(ctx context.Context, target string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", textContentType)
+	if p.opt.GetUsername() != "" {
+		req.SetBasicAuth(p.opt.GetUsername(), p.opt.GetPassword())
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("pushgateway returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+func (p *Pusher) url() (string, error) {
+	if strings.TrimSpace(p.opt.GetEndpoint()) == "" {
+		return "", fmt.Errorf("pushgateway endpoint is empty")
+	}
+	if strings.TrimSpace(p.opt.GetJob()) == "" {
+		return "", fmt.Errorf("pushgateway job is empty")
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(p.opt.GetEndpoint(), "/"))
+	sb.WriteString("/metrics/job/")
+	sb.WriteString(url.PathEscape(p.opt.GetJob()))
+	keys := make([]string, 0, len(p.opt.GetGrouping()))
+	for k := range p.opt.GetGrouping() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString("/")
+		sb.WriteString(url.PathEscape(k))
+		sb.WriteString("/")
+		sb.WriteString(url.PathEscape(p.opt.GetGrouping()[k]))
+	}
+	return sb.String(), nil
+}
+
+func (p *Pusher) interval() time.Duration {
+	if p.opt.GetInterval() <= 0 {
+		return defaultPushInterval
+	}
+	return time.Duration(p.opt.GetInterval()) * time.Second
+}
+
+func (p *Pusher) batchSize() int {
+	if p.opt.GetBatchSize() <= 0 {
+		return defaultPushBatchSize
+	}
+	return int(p.opt.GetBatchSize())
+}
+
+func (p *Pusher) maxRetries() int {
+	if p.opt.GetMaxRetries() <= 0 {
+		return defaultPushMaxRetries
+	}
+	return int(p.opt.GetMaxRetries())
+}
+
+// encodeFamilies renders every collected metric as one block of the Prometheus text exposition format.
+func encodeFamilies(rm *metricdata.ResourceMetrics) []string {
+	var families []string
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			var sb strings.Builder
+			name := sanitizeName(m.Name)
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				writeSum(&sb, name, m.Description, data.IsMonotonic, data.DataPoints)
+			case metricdata.Sum[float64]:
+				writeSum(&sb, name, m.Description, data.IsMonotonic, data.DataPoints)
+			case metricdata.Gauge[int64]:
+				writeSum(&sb, name, m.Description, false, data.DataPoints)
+			case metricdata.Gauge[float64]:
+				writeSum(&sb, name, m.Description, false, data.DataPoints)
+			case metricdata.Histogram[int64]:
+				writeHistogram(&sb, name, m.Description, data.DataPoints)
+			case metricdata.Histogram[float64]:
+				writeHistogram(&sb, name, m.Description, data.DataPoints)
+			default:
+				continue
+			}
+			families = append(families, sb.String())
+		}
+	}
+	return families
+}
+
+func writeSum[N int64 | float64](sb *strings.Builder, name, help string, monotonic bool, points []metricdata.DataPoint[N]) {
+	typ := "gauge"
+	if monotonic {
+		typ = "counter"
+		if !strings.HasSuffix(name, "_total") {
+			name += "_total"
+		}
+	}
+	writeHeader(sb, name, help, typ)
+	for _, dp := range points {
+		writeSample(sb, name, labelPairs(dp.Attributes), float64(dp.Value))
+	}
+}
+
+func writeHistogram[N int64 | float64](sb *strings.Builder, name, help string, points []metricdata.HistogramDataPoint[N]) {
+	writeHeader(sb, name, help, "histogram")
+	for _, dp := range points {
+		labels := labelPairs(dp.Attributes)
+		var cumulative uint64
+		for i, bound := range dp.Bounds {
+			if i < len(dp.BucketCounts) {
+				cumulative += dp.BucketCounts[i]
+			}
+			writeSample(sb, name+"_bucket", append(labels, fmt.Sprintf(`le="%s"`, formatFloat(bound))), float64(cumulative))
+		}
+		writeSample(sb, name+"_bucket", append(labels, `le="+Inf"`), float64(dp.Count))
+		writeSample(sb, name+"_sum", labels, float64(dp.Sum))
+		writeSample(sb, name+"_count", labels, float64(dp.Count))
+	}
+}
+
+func writeHeader(sb *strings.Builder, name, help, typ string) {
+	if help != "" {
+		fmt.Fprintf(sb, "# HELP %s %s\n", name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help))
+	}
+	fmt.Fprintf(sb, "# TYPE %s %s\n", name, typ)
+}
+
+func writeSample(sb *strings.Builder, name string, labels []string, value float64) {
+	sb.WriteString(name)
+	if len(labels) > 0 {
+		sb.WriteString("{")
+		sb.WriteString(strings.Join(labels, ","))
+		sb.WriteString("}")
+	}
+	sb.WriteString(" ")
+	sb.WriteString(formatFloat(value))
+	sb.WriteString("\n")
+}
+
+func labelPairs(set attribute.Set) []string {
+	labels := make([]string, 0, set.Len())
+	iter := set.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		value := strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(kv.Value.Emit())
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, sanitizeName(string(kv.Key)), value))
+	}
+	return labels
+}
+
+func sanitizeName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == ':' || c >= '0' && c <= '9' && i > 0) {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,96 @@
+package baggage
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	TenantKey       = "tenant"
+	ExperimentIDKey = "experiment_id"
+)
+
+var propagator = propagation.Baggage{}
+
+// Server extracts W3C baggage from the request headers into the context.
+func Server() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				ctx = propagator.Extract(ctx, tr.RequestHeader())
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Client injects the baggage of the context into the outgoing request headers.
+func Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromClientContext(ctx); ok {
+				propagator.Inject(ctx, tr.RequestHeader())
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Value returns the baggage member key of ctx, or an empty string.
+func Value(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// WithValue returns a copy of ctx whose baggage carries key=value.
+func WithValue(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, err
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, err
+	}
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+func Tenant(ctx context.Context) string {
+	return Value(ctx, TenantKey)
+}
+
+func WithTenant(ctx context.Context, tenant string) (context.Context, error) {
+	return WithValue(ctx, TenantKey, tenant)
+}
+
+func ExperimentID(ctx context.Context) string {
+	return Value(ctx, ExperimentIDKey)
+}
+
+func WithExperimentID(ctx context.Context, id string) (context.Context, error) {
+	return WithValue(ctx, ExperimentIDKey, id)
+}
+
+// Valuer returns a log valuer for the baggage member key, e.g. log.With(logger, "tenant", baggage.Valuer("tenant")).
+func Valuer(key string) log.Valuer {
+	return func(ctx context.Context) interface{} {
+		return Value(ctx, key)
+	}
+}
+
+// Attributes returns the given baggage members as attributes, for use as metric labels.
+// Members missing from ctx are skipped.
+func Attributes(ctx context.Context, keys ...string) []attribute.KeyValue {
+	bag := baggage.FromContext(ctx)
+	attrs := make([]attribute.KeyValue, 0, len(keys))
+	for _, key := range keys {
+		if member := bag.Member(key); member.Key() != "" {
+			attrs = append(attrs, attribute.String(key, member.Value()))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,108 @@
+package cors
+
+import (
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"sync/atomic"
+)
+
+// ServerWithConfig builds Server from the CorsOption stored at key of c; opts are applied
+// after the configuration.
+func ServerWithConfig(c config.Config, key string, opts ...Option) (middleware.Middleware, error) {
+	o, err := loadOptions(c, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	return server(func() *options { return o }), nil
+}
+
+// ServerWithConfigWatch is ServerWithConfig reloading the options whenever key changes,
+// so allowed origins can change without a restart.
+func ServerWithConfigWatch(c config.Config, key string, opts ...Option) (middleware.Middleware, error) {
+	current, err := watchOptions(c, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	return server(current.Load), nil
+}
+
+// FilterWithConfigWatch is the server filter counterpart of ServerWithConfigWatch.
+func FilterWithConfigWatch(c config.Config, key string, opts ...Option) (khttp.FilterFunc, error) {
+	current, err := watchOptions(c, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	return filter(current.Load), nil
+}
+
+// corsConfig mirrors the cors.CorsOption message for scanning configuration sources.
+type corsConfig struct {
+	Origins              []string               `json:"origins"`
+	Methods              []string               `json:"methods"`
+	Headers              []string               `json:"headers"`
+	AllowCredentials     bool                   `json:"allow_credentials"`
+	OriginPatterns       []string               `json:"origin_patterns"`
+	MaxAge               int32                  `json:"max_age"`
+	OriginMaxAge         map[string]int32       `json:"origin_max_age"`
+	Profiles             map[string]*corsConfig `json:"profiles"`
+	OptionsPassthrough   bool                   `json:"options_passthrough"`
+	OptionsSuccessStatus int32                  `json:"options_success_status"`
+}
+
+func (c *corsConfig) GetOrigins() []string              { return c.Origins }
+func (c *corsConfig) GetOriginPatterns() []string       { return c.OriginPatterns }
+func (c *corsConfig) GetMethods() []string              { return c.Methods }
+func (c *corsConfig) GetHeaders() []string              { return c.Headers }
+func (c *corsConfig) GetAllowCredentials() bool         { return c.AllowCredentials }
+func (c *corsConfig) GetMaxAge() int32                  { return c.MaxAge }
+func (c *corsConfig) GetOriginMaxAge() map[string]int32 { return c.OriginMaxAge }
+
+func (c *corsConfig) GetOptionsPassthrough() bool    { return c.OptionsPassthrough }
+func (c *corsConfig) GetOptionsSuccessStatus() int32 { return c.OptionsSuccessStatus }
+
+func (c *corsConfig) CorsProfiles() map[string]CorsConfig {
+	profiles := make(map[string]CorsConfig, len(c.Profiles))
+	for host, profile := range c.Profiles {
+		profiles[host] = profile
+	}
+	return profiles
+}
+
+func loadOptions(c config.Config, key string, opts []Option) (*options, error) {
+	opt := &corsConfig{}
+	if err := c.Value(key).Scan(opt); err != nil {
+		return nil, err
+	}
+	if err := ValidateConfig(opt); err != nil {
+		return nil, err
+	}
+	o := newOptions(append([]Option{WithCorsOption(opt)}, opts...)...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func watchOptions(c config.Config, key string, opts []Option) (*atomic.Pointer[options], error) {
+	o, err := loadOptions(c, key, opts)
+	if err != nil {
+		return nil, err
+	}
+	current := &atomic.Pointer[options]{}
+	current.Store(o)
+	err = c.Watch(key, func(key string, _ config.Value) {
+		o, err := loadOptions(c, key, opts)
+		if err != nil {
+			log.Errorf("reload cors config <%s> error -> %s", key, err.Error())
+			return
+		}
+		current.Store(o)
+		log.Infof("reloaded cors config <%s>", key)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
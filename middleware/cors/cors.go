@@ -0,0 +1,442 @@
+package cors
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerOrigin           = "Origin"
+	headerRequestMethod    = "Access-Control-Request-Method"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	headerVary             = "Vary"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerExposeHeaders    = "Access-Control-Expose-Headers"
+	headerAllowCredentials = "Access-Control-Allow-Credentials"
+	headerMaxAge           = "Access-Control-Max-Age"
+	headerForwardedProto   = "X-Forwarded-Proto"
+)
+
+var (
+	// ErrOriginRejected is returned in strict mode for requests from disallowed origins.
+	ErrOriginRejected = errors.Forbidden("CORS_ORIGIN_REJECTED", "cross-origin request rejected")
+	// ErrMethodRejected is returned in strict mode for preflights of disallowed methods.
+	ErrMethodRejected = errors.Forbidden("CORS_METHOD_REJECTED", "cross-origin method rejected")
+	// ErrHeadersRejected is returned in strict mode for preflights of disallowed headers.
+	ErrHeadersRejected = errors.Forbidden("CORS_HEADERS_REJECTED", "cross-origin headers rejected")
+)
+
+type header interface {
+	Get(key string) string
+	Set(key, value string)
+	Add(key, value string)
+	Values(key string) []string
+}
+
+// Server is a kratos middleware enforcing CORS on HTTP requests. Routed preflight
+// requests are answered with an empty reply without reaching the handler, so logging
+// and metrics middleware see a success and the route's encoder writes 200. Use Filter
+// to answer preflights before routing, with the options success status and also for
+// unrouted paths. It panics on invalid options, ServerWithConfig returns them as an error.
+func Server(opts ...Option) middleware.Middleware {
+	o := mustNewOptions(opts...)
+	return server(func() *options { return o })
+}
+
+func server(current func() *options) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			ht, ok := tr.(khttp.Transporter)
+			if !ok {
+				return handler(ctx, req)
+			}
+			o := current()
+			done, err := o.handle(ctx, ht.Request(), ht.RequestHeader(), ht.ReplyHeader())
+			if err != nil {
+				return nil, err
+			}
+			if done && !o.passthrough {
+				// the middleware can't set the status, an error would be logged as a failure
+				return nil, nil
+			}
+			reply, err := handler(ctx, req)
+			if err != nil {
+				o.restoreHeaders(ctx, ht.Request(), ht.ReplyHeader())
+			}
+			return reply, err
+		}
+	}
+}
+
+// handle writes the CORS headers for a request and reports whether it was a preflight
+// request that must not reach the handler.
+func (o *options) handle(ctx context.Context, r *http.Request, req, reply header) (bool, error) {
+	if profile := o.profile(r.Host); profile != nil {
+		return profile.handle(ctx, r, req, reply)
+	}
+	origin := req.Get(headerOrigin)
+	preflight := origin != "" && r.Method == http.MethodOptions && req.Get(headerRequestMethod) != ""
+	// the response depends on these request headers, shared caches must key on them
+	switch {
+	case preflight:
+		addVary(reply, headerOrigin, headerRequestMethod, headerRequestHeaders)
+	case !o.allowsAnyOrigin():
+		addVary(reply, headerOrigin)
+	}
+	if origin == "" {
+		return false, nil
+	}
+	requestHeaders := parseHeaderList(req.Values(headerRequestHeaders))
+	if !o.isOriginAllowed(ctx, origin) {
+		if !preflight && o.isSameOrigin(origin, r) {
+			// not a cross-origin request, the service's own pages need no allow-list entry
+			return false, nil
+		}
+		o.metrics.record(ctx, origin, preflight, false)
+		o.log.WithContext(ctx).Warnw("msg", "cors origin rejected", "origin", origin, "method", r.Method, "path", r.URL.Path)
+		if o.strict {
+			return true, ErrOriginRejected.WithMetadata(map[string]string{
+				"origin": origin,
+				"method": r.Method,
+				"path":   r.URL.Path,
+				"reason": "origin is not in the allowed origins",
+			})
+		}
+		return preflight, nil
+	}
+	if preflight && !o.isMethodAllowed(req.Get(headerRequestMethod)) {
+		o.metrics.record(ctx, origin, preflight, false)
+		o.log.WithContext(ctx).Warnw("msg", "cors method rejected", "origin", origin, "method", req.Get(headerRequestMethod), "path", r.URL.Path)
+		if o.strict {
+			return true, ErrMethodRejected.WithMetadata(map[string]string{
+				"origin": origin,
+				"method": req.Get(headerRequestMethod),
+				"path":   r.URL.Path,
+				"reason": "method is not in the allowed methods",
+			})
+		}
+		return true, nil
+	}
+	if preflight && !o.areHeadersAllowed(requestHeaders) {
+		o.metrics.record(ctx, origin, preflight, false)
+		o.log.WithContext(ctx).Warnw("msg", "cors headers rejected", "origin", origin, "headers", strings.Join(requestHeaders, ", "), "path", r.URL.Path)
+		if o.strict {
+			return true, ErrHeadersRejected.WithMetadata(map[string]string{
+				"origin":  origin,
+				"headers": strings.Join(requestHeaders, ", "),
+				"path":    r.URL.Path,
+				"reason":  "headers are not in the allowed headers",
+			})
+		}
+		return true, nil
+	}
+	o.metrics.record(ctx, origin, preflight, true)
+	o.writeHeaders(origin, preflight, requestHeaders, reply)
+	return preflight, nil
+}
+
+func (o *options) writeHeaders(origin string, preflight bool, requestHeaders []string, reply header) {
+	// echoing any origin together with credentials would let every site read credentialed
+	// responses, "*" is answered literally and never with credentials
+	if o.allowsAnyOrigin() {
+		reply.Set(headerAllowOrigin, "*")
+	} else {
+		reply.Set(headerAllowOrigin, origin)
+		if o.allowCredentials {
+			reply.Set(headerAllowCredentials, "true")
+		}
+	}
+	if !preflight {
+		if len(o.exposedHeaders) > 0 {
+			reply.Set(headerExposeHeaders, strings.Join(o.exposedHeaders, ", "))
+		}
+		return
+	}
+	reply.Set(headerAllowMethods, strings.Join(o.allowedMethods, ", "))
+	// echo the requested headers, a literal "*" isn't honored for credentialed requests
+	if len(requestHeaders) > 0 {
+		reply.Set(headerAllowHeaders, strings.Join(requestHeaders, ", "))
+	}
+	if maxAge := o.maxAgeFor(origin); maxAge > 0 {
+		reply.Set(headerMaxAge, strconv.Itoa(int(maxAge.Seconds())))
+	}
+}
+
+// restoreHeaders writes the headers of an allowed actual request unless present, for
+// error responses whose headers were dropped or never written.
+func (o *options) restoreHeaders(ctx context.Context, r *http.Request, reply header) {
+	if profile := o.profile(r.Host); profile != nil {
+		profile.restoreHeaders(ctx, r, reply)
+		return
+	}
+	origin := r.Header.Get(headerOrigin)
+	if origin == "" || reply.Get(headerAllowOrigin) != "" || !o.isOriginAllowed(ctx, origin) {
+		return
+	}
+	if !o.allowsAnyOrigin() {
+		addVary(reply, headerOrigin)
+	}
+	o.writeHeaders(origin, false, nil, reply)
+}
+
+func addVary(reply header, values ...string) {
+	var present []string
+	for _, v := range reply.Values(headerVary) {
+		for _, token := range strings.Split(v, ",") {
+			present = append(present, strings.ToLower(strings.TrimSpace(token)))
+		}
+	}
+	for _, value := range values {
+		if !slices.Contains(present, strings.ToLower(value)) {
+			reply.Add(headerVary, value)
+		}
+	}
+}
+
+// isSameOrigin reports whether origin names the scheme, host and port the request was
+// sent to. Browsers send Origin on same-origin POST, PUT and DELETE requests too.
+func (o *options) isSameOrigin(origin string, r *http.Request) bool {
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || r.Host == "" {
+		return false
+	}
+	requestScheme := "http"
+	if r.TLS != nil {
+		requestScheme = "https"
+	}
+	if proto := r.Header.Get(headerForwardedProto); o.forwardedProto && proto != "" {
+		requestScheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+	if !strings.EqualFold(scheme, requestScheme) {
+		return false
+	}
+	hostname, port := splitPort(host, scheme)
+	requestHostname, requestPort := splitPort(r.Host, requestScheme)
+	return strings.EqualFold(hostname, requestHostname) && port == requestPort
+}
+
+func (o *options) isOriginAllowed(ctx context.Context, origin string) bool {
+	for _, allowed := range o.allowedOrigins {
+		if matchOrigin(allowed, origin, o.legacyWildcard) {
+			return true
+		}
+	}
+	for _, re := range o.originPatterns {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+	for _, source := range o.originSources {
+		if source.allows(origin, o.legacyWildcard) {
+			return true
+		}
+	}
+	return o.allowOriginFunc != nil && o.allowOriginFunc(ctx, origin)
+}
+
+// matchOrigin reports whether origin matches an allowed entry: an exact origin, "*",
+// a subdomain wildcard like "https://*.example.com", or a loopback host on any port
+// like "http://localhost:*". A subdomain wildcard without scheme matches https only,
+// unless legacy matching ignores the scheme.
+func matchOrigin(allowed, origin string, legacy bool) bool {
+	if allowed == "*" || strings.EqualFold(allowed, origin) {
+		return true
+	}
+	scheme, host, ok := strings.Cut(allowed, "://")
+	if !ok {
+		scheme, host = "", allowed
+	}
+	if hostname, ok := strings.CutSuffix(host, ":*"); ok {
+		return matchAnyPort(scheme, hostname, origin)
+	}
+	if !strings.HasPrefix(host, "*.") {
+		return false
+	}
+	originScheme, originHost, ok := strings.Cut(origin, "://")
+	if !ok {
+		return false
+	}
+	if legacy && scheme == "" {
+		return hasSuffixFold(originHost, host[1:])
+	}
+	if scheme == "" {
+		scheme = "https"
+	}
+	if !strings.EqualFold(scheme, originScheme) {
+		return false
+	}
+	suffix, port := splitPort(host[1:], scheme)
+	hostname, originPort := splitPort(originHost, originScheme)
+	return port == originPort && hasSuffixFold(hostname, suffix)
+}
+
+// matchAnyPort matches "http://localhost:*" style entries, which are limited to
+// loopback hosts so a port wildcard never opens a public host.
+func matchAnyPort(scheme, hostname, origin string) bool {
+	if !isLoopback(hostname) {
+		return false
+	}
+	originScheme, originHost, ok := strings.Cut(origin, "://")
+	if !ok || !strings.EqualFold(scheme, originScheme) {
+		return false
+	}
+	originHostname, _ := splitPort(originHost, originScheme)
+	return strings.EqualFold(hostname, originHostname)
+}
+
+func isLoopback(hostname string) bool {
+	switch strings.ToLower(hostname) {
+	case "localhost", "127.0.0.1", "[::1]":
+		return true
+	}
+	return false
+}
+
+func hasSuffixFold(s, suffix string) bool {
+	return strings.HasSuffix(strings.ToLower(s), strings.ToLower(suffix))
+}
+
+// splitPort splits host into hostname and port, defaulting the port from scheme.
+func splitPort(host, scheme string) (string, string) {
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.HasSuffix(host, "]") {
+		return host[:i], host[i+1:]
+	}
+	switch strings.ToLower(scheme) {
+	case "http":
+		return host, "80"
+	case "https":
+		return host, "443"
+	}
+	return host, ""
+}
+
+// profile returns the host profile for host, ignoring case and port.
+func (o *options) profile(host string) *options {
+	if len(o.profiles) == 0 {
+		return nil
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return o.profiles[strings.ToLower(host)]
+}
+
+func (o *options) maxAgeFor(origin string) time.Duration {
+	for _, rule := range o.originMaxAge {
+		if matchOrigin(rule.origin, origin, o.legacyWildcard) {
+			return rule.maxAge
+		}
+	}
+	return o.maxAge
+}
+
+func (o *options) isMethodAllowed(method string) bool {
+	for _, allowed := range o.allowedMethods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// areHeadersAllowed matches headers case-insensitively, "*" allows any header.
+func (o *options) areHeadersAllowed(headers []string) bool {
+	if slices.Contains(o.allowedHeaders, "*") {
+		return true
+	}
+	for _, header := range headers {
+		if !slices.ContainsFunc(o.allowedHeaders, func(allowed string) bool {
+			return strings.EqualFold(allowed, header)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseHeaderList(values []string) []string {
+	var headers []string
+	for _, value := range values {
+		for _, header := range strings.Split(value, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				headers = append(headers, header)
+			}
+		}
+	}
+	return headers
+}
+
+func (o *options) allowsAnyOrigin() bool {
+	for _, allowed := range o.allowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter is a kratos HTTP server filter enforcing CORS before routing, so preflight
+// requests to paths without an OPTIONS route are answered instead of returning 404.
+// Like Server, it panics on invalid options.
+func Filter(opts ...Option) khttp.FilterFunc {
+	o := mustNewOptions(opts...)
+	return filter(func() *options { return o })
+}
+
+// Handler is the net/http middleware counterpart of Filter.
+func Handler(opts ...Option) func(http.Handler) http.Handler {
+	return Filter(opts...)
+}
+
+// NewHandler is Handler returning invalid options as an error instead of panicking.
+func NewHandler(opts ...Option) (func(http.Handler) http.Handler, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return filter(func() *options { return o }), nil
+}
+
+// ErrorEncoder wraps next so error responses carry the CORS headers of allowed origins,
+// including errors returned by middleware running before Server, e.g.
+// khttp.ErrorEncoder(cors.ErrorEncoder(khttp.DefaultErrorEncoder)).
+func ErrorEncoder(next khttp.EncodeErrorFunc, opts ...Option) khttp.EncodeErrorFunc {
+	o := mustNewOptions(opts...)
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		o.restoreHeaders(r.Context(), r, w.Header())
+		next(w, r, err)
+	}
+}
+
+func filter(current func() *options) khttp.FilterFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			o := current()
+			done, err := o.handle(req.Context(), req, req.Header, w.Header())
+			if err != nil {
+				khttp.DefaultErrorEncoder(w, req, err)
+				return
+			}
+			if done && !o.passthrough {
+				w.WriteHeader(o.successStatus)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package cors
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/middleware"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serve(t *testing.T, h http.Handler, method, origin string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, "https://api.example.com/v1/items", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if origin != "" {
+		req.Header.Set(headerOrigin, origin)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+var ok = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestCredentialsNeverEchoAnyOrigin(t *testing.T) {
+	// credentials with "*" are invalid, but options built without validation must not
+	// turn them into a reflected origin either
+	o := newOptions(WithAllowCredentials(true))
+	reply := http.Header{}
+	o.writeHeaders("https://evil.example", false, nil, reply)
+	if got := reply.Get(headerAllowOrigin); got != "*" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+	if got := reply.Get(headerAllowCredentials); got != "" {
+		t.Fatalf("Access-Control-Allow-Credentials = %q, want none", got)
+	}
+}
+
+func TestCredentialsEchoListedOrigin(t *testing.T) {
+	h := Handler(WithAllowedOrigins("https://app.example.com"), WithAllowCredentials(true))(ok)
+	rec := serve(t, h, http.MethodGet, "https://app.example.com", nil)
+	if got := rec.Header().Get(headerAllowOrigin); got != "https://app.example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q", got)
+	}
+	if got := rec.Header().Get(headerAllowCredentials); got != "true" {
+		t.Fatalf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+}
+
+func TestConstructorsRejectInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"production without origins", Production()},
+		{"production with any origin", Production("*")},
+		{"credentials with any origin", []Option{WithAllowCredentials(true)}},
+		{"negative max age", []Option{WithMaxAge(-1)}},
+		{"invalid origin pattern", []Option{WithAllowedOriginPatterns("[")}},
+	}
+	constructors := map[string]func(opts ...Option){
+		"Server":    func(opts ...Option) { Server(opts...) },
+		"Filter":    func(opts ...Option) { Filter(opts...) },
+		"Handler":   func(opts ...Option) { Handler(opts...) },
+		"NewPolicy": func(opts ...Option) { NewPolicy(opts...) },
+	}
+	for _, tt := range tests {
+		for name, construct := range constructors {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				defer func() {
+					if recover() == nil {
+						t.Fatal("expected a panic")
+					}
+				}()
+				construct(tt.opts...)
+			})
+		}
+	}
+}
+
+func TestProductionAllowsListedOrigins(t *testing.T) {
+	h := Handler(Production("https://app.example.com")...)(ok)
+	if got := serve(t, h, http.MethodGet, "https://other.example.com", nil).Header().Get(headerAllowOrigin); got != "" {
+		t.Fatalf("unlisted origin allowed: %q", got)
+	}
+	if got := serve(t, h, http.MethodGet, "https://app.example.com", nil).Header().Get(headerAllowOrigin); got != "https://app.example.com" {
+		t.Fatalf("listed origin: Access-Control-Allow-Origin = %q", got)
+	}
+}
+
+func TestServerAnswersPreflightWithoutError(t *testing.T) {
+	var called bool
+	var outerErr error
+	outer := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			reply, err := handler(ctx, req)
+			outerErr = err
+			return reply, err
+		}
+	}
+	srv := khttp.NewServer(khttp.Middleware(outer, Server(WithAllowedOrigins("https://app.example.com"))))
+	srv.Route("/").OPTIONS("/v1/items", func(ctx khttp.Context) error {
+		h := ctx.Middleware(func(context.Context, interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		reply, err := h(ctx, nil)
+		if err != nil {
+			return err
+		}
+		return ctx.Result(http.StatusOK, reply)
+	})
+	rec := serve(t, srv, http.MethodOptions, "https://app.example.com", http.Header{headerRequestMethod: {"PUT"}})
+	if called {
+		t.Fatal("preflight reached the handler")
+	}
+	if outerErr != nil {
+		t.Fatalf("outer middleware saw error %v", outerErr)
+	}
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("status %d body %q, want 200 and no body", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get(headerAllowMethods); got == "" {
+		t.Fatal("missing Access-Control-Allow-Methods")
+	}
+}
+
+func TestRegisterPreflight(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(srv *khttp.Server)
+		origin   string
+		allowed  string
+	}{
+		{"defaults", func(srv *khttp.Server) { RegisterPreflight(srv, "/v1/items") }, "https://app.example.com", "*"},
+		{"catch-all", func(srv *khttp.Server) { RegisterPreflight(srv) }, "https://app.example.com", "*"},
+		{"policy", func(srv *khttp.Server) {
+			NewPolicy(WithAllowedOrigins("https://app.example.com")).RegisterPreflight(srv, "/v1/items")
+		}, "https://app.example.com", "https://app.example.com"},
+		{"policy rejects", func(srv *khttp.Server) {
+			NewPolicy(WithAllowedOrigins("https://app.example.com")).RegisterPreflight(srv, "/v1/items")
+		}, "https://other.example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := khttp.NewServer()
+			tt.register(srv)
+			rec := serve(t, srv, http.MethodOptions, tt.origin, http.Header{headerRequestMethod: {"POST"}})
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("status %d, want 204", rec.Code)
+			}
+			if got := rec.Header().Get(headerAllowOrigin); got != tt.allowed {
+				t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	h := Handler(WithAllowedOrigins("https://app.example.com"), WithStrictMode(true))(ok)
+	tests := []struct {
+		name   string
+		method string
+		origin string
+		header http.Header
+		code   int
+	}{
+		{"allowed origin", http.MethodPost, "https://app.example.com", nil, http.StatusOK},
+		{"same origin", http.MethodPost, "https://api.example.com", nil, http.StatusOK},
+		{"same origin explicit port", http.MethodDelete, "https://api.example.com:443", nil, http.StatusOK},
+		{"same host other port", http.MethodPost, "https://api.example.com:8443", nil, http.StatusForbidden},
+		{"same host other scheme", http.MethodPost, "http://api.example.com", nil, http.StatusForbidden},
+		{"forwarded proto untrusted", http.MethodPost, "http://api.example.com", http.Header{headerForwardedProto: {"http"}}, http.StatusForbidden},
+		{"foreign origin", http.MethodPost, "https://evil.example", nil, http.StatusForbidden},
+		{"foreign preflight", http.MethodOptions, "https://evil.example", http.Header{headerRequestMethod: {"PUT"}}, http.StatusForbidden},
+		{"no origin", http.MethodPost, "", nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := serve(t, h, tt.method, tt.origin, tt.header); rec.Code != tt.code {
+				t.Fatalf("status %d, want %d", rec.Code, tt.code)
+			}
+		})
+	}
+}
+
+func TestStrictModeBehindProxy(t *testing.T) {
+	h := Handler(WithAllowedOrigins("https://app.example.com"), WithStrictMode(true), WithForwardedProto(true))(ok)
+	plain := func(method, origin string, header http.Header) int {
+		req := httptest.NewRequest(method, "http://api.example.com/v1/items", nil)
+		req.Header = header
+		req.Header.Set(headerOrigin, origin)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	tests := []struct {
+		name   string
+		origin string
+		proto  string
+		code   int
+	}{
+		{"https behind proxy", "https://api.example.com", "https", http.StatusOK},
+		{"first forwarded proto", "https://api.example.com", "https, http", http.StatusOK},
+		{"http origin to https", "http://api.example.com", "https", http.StatusForbidden},
+		{"no header", "http://api.example.com", "", http.StatusOK},
+		{"https origin to plain http", "https://api.example.com", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.proto != "" {
+				header.Set(headerForwardedProto, tt.proto)
+			}
+			if code := plain(http.MethodPost, tt.origin, header); code != tt.code {
+				t.Fatalf("status %d, want %d", code, tt.code)
+			}
+		})
+	}
+}
+
+func TestMatchOrigin(t *testing.T) {
+	tests := []struct {
+		allowed string
+		origin  string
+		legacy  bool
+		want    bool
+	}{
+		{"*", "https://any.example", false, true},
+		{"https://app.example.com", "https://app.example.com", false, true},
+		{"https://app.example.com", "HTTPS://APP.EXAMPLE.COM", false, true},
+		{"https://app.example.com", "http://app.example.com", false, false},
+		{"https://app.example.com", "https://app.example.com:8443", false, false},
+		{"https://*.example.com", "https://a.example.com", false, true},
+		{"https://*.example.com", "https://a.b.example.com", false, true},
+		{"https://*.example.com", "https://example.com", false, false},
+		{"https://*.example.com", "https://evilexample.com", false, false},
+		{"https://*.example.com", "http://a.example.com", false, false},
+		{"https://*.example.com", "https://a.example.com:8443", false, false},
+		{"https://*.example.com", "https://a.example.com:443", false, true},
+		{"https://*.example.com:8443", "https://a.example.com:8443", false, true},
+		{"*.example.com", "https://a.example.com", false, true},
+		{"*.example.com", "http://a.example.com", false, false},
+		{"*.example.com", "http://a.example.com", true, true},
+		{"*.example.com", "wss://a.example.com", true, true},
+		{"*.example.com", "http://a.example.com:8080", true, false},
+		{"http://localhost:*", "http://localhost:3000", false, true},
+		{"http://localhost:*", "http://localhost", false, true},
+		{"http://localhost:*", "https://localhost:3000", false, false},
+		{"http://127.0.0.1:*", "http://127.0.0.1:5173", false, true},
+		{"http://[::1]:*", "http://[::1]:5173", false, true},
+		{"https://example.com:*", "https://example.com:8443", false, false},
+		{"https://app.example.com", "null", false, false},
+	}
+	for _, tt := range tests {
+		if got := matchOrigin(tt.allowed, tt.origin, tt.legacy); got != tt.want {
+			t.Errorf("matchOrigin(%q, %q, legacy=%v) = %v, want %v", tt.allowed, tt.origin, tt.legacy, got, tt.want)
+		}
+	}
+}
+
+func TestOriginAllowed(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		origin string
+		want   string
+		creds  string
+	}{
+		{"any origin", nil, "https://a.example", "*", ""},
+		{"listed", []Option{WithAllowedOrigins("https://a.example")}, "https://a.example", "https://a.example", ""},
+		{"not listed", []Option{WithAllowedOrigins("https://a.example")}, "https://b.example", "", ""},
+		{"pattern", []Option{WithAllowedOrigins(), WithAllowedOriginPatterns(`^https://pr-\d+\.preview\.example\.com$`)},
+			"https://pr-42.preview.example.com", "https://pr-42.preview.example.com", ""},
+		{"pattern mismatch", []Option{WithAllowedOrigins(), WithAllowedOriginPatterns(`^https://pr-\d+\.preview\.example\.com$`)},
+			"https://pr-x.preview.example.com", "", ""},
+		{"func", []Option{WithAllowedOrigins(), WithAllowOriginFunc(func(_ context.Context, origin string) bool {
+			return origin == "https://tenant.example"
+		})}, "https://tenant.example", "https://tenant.example", ""},
+		{"wildcard with credentials", []Option{WithAllowedOrigins("https://*.example.com"), WithAllowCredentials(true)},
+			"https://a.example.com", "https://a.example.com", "true"},
+		{"pattern with credentials", []Option{WithAllowedOrigins(), WithAllowedOriginPatterns(`^https://[a-z]+\.example\.com$`), WithAllowCredentials(true)},
+			"https://a.example.com", "https://a.example.com", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, Handler(tt.opts...)(ok), http.MethodGet, tt.origin, nil)
+			if got := rec.Header().Get(headerAllowOrigin); got != tt.want {
+				t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, tt.want)
+			}
+			if got := rec.Header().Get(headerAllowCredentials); got != tt.creds {
+				t.Fatalf("Access-Control-Allow-Credentials = %q, want %q", got, tt.creds)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package cors
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type debugOptions struct {
+	AllowedOrigins   []string                `json:"allowed_origins"`
+	OriginPatterns   []string                `json:"origin_patterns"`
+	OriginSources    [][]string              `json:"origin_sources"`
+	AllowOriginFunc  bool                    `json:"allow_origin_func"`
+	AllowedMethods   []string                `json:"allowed_methods"`
+	AllowedHeaders   []string                `json:"allowed_headers"`
+	ExposedHeaders   []string                `json:"exposed_headers"`
+	AllowCredentials bool                    `json:"allow_credentials"`
+	MaxAge           string                  `json:"max_age"`
+	OriginMaxAge     map[string]string       `json:"origin_max_age"`
+	Strict           bool                    `json:"strict"`
+	LegacyWildcard   bool                    `json:"legacy_wildcard"`
+	Profiles         map[string]debugOptions `json:"profiles,omitempty"`
+}
+
+type debugEvaluation struct {
+	Origin  string `json:"origin"`
+	Method  string `json:"method,omitempty"`
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+	MaxAge  string `json:"max_age,omitempty"`
+}
+
+type debugReply struct {
+	Options    debugOptions     `json:"options"`
+	Evaluation *debugEvaluation `json:"evaluation,omitempty"`
+}
+
+// DebugHandler returns the resolved CORS options as JSON. With an origin query
+// parameter, and optionally method, headers and host, it also explains whether a preflight
+// from that origin is allowed. It exposes the policy, mount it on internal ports only.
+func DebugHandler(opts ...Option) http.Handler {
+	o := mustNewOptions(opts...)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		reply := debugReply{Options: o.debug()}
+		if origin := req.URL.Query().Get("origin"); origin != "" {
+			o := o
+			if profile := o.profile(req.URL.Query().Get("host")); profile != nil {
+				o = profile
+			}
+			reply.Evaluation = o.evaluate(req, origin, req.URL.Query().Get("method"), req.URL.Query()["headers"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(reply)
+	})
+}
+
+func (o *options) debug() debugOptions {
+	d := debugOptions{
+		AllowedOrigins:   o.allowedOrigins,
+		AllowOriginFunc:  o.allowOriginFunc != nil,
+		AllowedMethods:   o.allowedMethods,
+		AllowedHeaders:   o.allowedHeaders,
+		ExposedHeaders:   o.exposedHeaders,
+		AllowCredentials: o.allowCredentials,
+		MaxAge:           o.maxAge.String(),
+		OriginMaxAge:     make(map[string]string, len(o.originMaxAge)),
+		Strict:           o.strict,
+		LegacyWildcard:   o.legacyWildcard,
+	}
+	for _, re := range o.originPatterns {
+		d.OriginPatterns = append(d.OriginPatterns, re.String())
+	}
+	for _, source := range o.originSources {
+		d.OriginSources = append(d.OriginSources, source.Origins())
+	}
+	for _, rule := range o.originMaxAge {
+		d.OriginMaxAge[rule.origin] = rule.maxAge.String()
+	}
+	if len(o.profiles) > 0 {
+		d.Profiles = make(map[string]debugOptions, len(o.profiles))
+		for host, profile := range o.profiles {
+			d.Profiles[host] = profile.debug()
+		}
+	}
+	return d
+}
+
+func (o *options) evaluate(req *http.Request, origin, method string, headers []string) *debugEvaluation {
+	e := &debugEvaluation{Origin: origin, Method: method}
+	switch {
+	case !o.isOriginAllowed(req.Context(), origin):
+		e.Reason = "origin matches no allowed origin, pattern, source or func"
+	case method != "" && !o.isMethodAllowed(method):
+		e.Reason = "method is not in the allowed methods"
+	case !o.areHeadersAllowed(parseHeaderList(headers)):
+		e.Reason = "headers are not in the allowed headers"
+	default:
+		e.Allowed = true
+		e.Reason = "allowed by " + o.matchedBy(origin)
+		e.MaxAge = o.maxAgeFor(origin).String()
+	}
+	return e
+}
+
+// matchedBy names the first rule allowing origin, in the order isOriginAllowed checks them.
+func (o *options) matchedBy(origin string) string {
+	for _, allowed := range o.allowedOrigins {
+		if matchOrigin(allowed, origin, o.legacyWildcard) {
+			return "allowed origin " + allowed
+		}
+	}
+	for _, re := range o.originPatterns {
+		if re.MatchString(origin) {
+			return "origin pattern " + re.String()
+		}
+	}
+	for _, source := range o.originSources {
+		if source.allows(origin, o.legacyWildcard) {
+			return "origin source"
+		}
+	}
+	return "allow origin func"
+}
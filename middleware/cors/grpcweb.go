@@ -0,0 +1,40 @@
+package cors
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var (
+	// GRPCWebHeaders are the request headers sent by gRPC-Web and grpc-gateway clients.
+	GRPCWebHeaders = []string{"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout", "Connect-Protocol-Version", "Connect-Timeout-Ms"}
+	// GRPCWebExposedHeaders are the response headers gRPC-Web and grpc-gateway clients read.
+	GRPCWebExposedHeaders = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin", "Grpc-Encoding", "Grpc-Metadata"}
+)
+
+// WithGRPCWeb adds GRPCWebHeaders to the allowed headers and GRPCWebExposedHeaders to
+// the exposed headers. Apply it after WithAllowedHeaders and WithExposedHeaders.
+func WithGRPCWeb() Option {
+	return func(o *options) {
+		o.allowedHeaders = appendFold(o.allowedHeaders, GRPCWebHeaders...)
+		o.exposedHeaders = appendFold(o.exposedHeaders, GRPCWebExposedHeaders...)
+	}
+}
+
+// GRPCWeb wraps a gRPC-Web handler (improbable-eng grpcweb.WrappedGrpcServer,
+// connect handlers) or a grpc-gateway runtime.ServeMux with CORS, allowing the
+// gRPC specific headers.
+func GRPCWeb(h http.Handler, opts ...Option) http.Handler {
+	return Handler(append(opts, WithGRPCWeb())...)(h)
+}
+
+func appendFold(headers []string, values ...string) []string {
+	headers = slices.Clone(headers)
+	for _, value := range values {
+		if !slices.ContainsFunc(headers, func(header string) bool { return strings.EqualFold(header, value) }) {
+			headers = append(headers, value)
+		}
+	}
+	return headers
+}
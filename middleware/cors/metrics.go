@@ -0,0 +1,76 @@
+package cors
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"net/url"
+	"sync"
+)
+
+// maxOriginHosts bounds the distinct origin_host values, further hosts are recorded as "other".
+const maxOriginHosts = 100
+
+type metrics struct {
+	preflight metric.Int64Counter
+	allowed   metric.Int64Counter
+	rejected  metric.Int64Counter
+	hosts     map[string]struct{}
+	m         *sync.Mutex
+}
+
+func newMetrics(mp metric.MeterProvider) *metrics {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter("github.com/cocosip/zero/middleware/cors")
+	// instrument errors leave a no-op counter, metrics must not break request handling
+	preflight, _ := meter.Int64Counter("cors.preflight.requests", metric.WithDescription("CORS preflight requests by origin host and result"))
+	allowed, _ := meter.Int64Counter("cors.allowed.requests", metric.WithDescription("Cross-origin requests allowed by origin host"))
+	rejected, _ := meter.Int64Counter("cors.rejected.origins", metric.WithDescription("Cross-origin requests rejected by origin host"))
+	return &metrics{
+		preflight: preflight,
+		allowed:   allowed,
+		rejected:  rejected,
+		hosts:     make(map[string]struct{}),
+		m:         &sync.Mutex{},
+	}
+}
+
+func (m *metrics) record(ctx context.Context, origin string, preflight, allowed bool) {
+	if m == nil || m.allowed == nil {
+		return
+	}
+	host := attribute.String("origin_host", m.originHost(origin))
+	if preflight {
+		result := "allowed"
+		if !allowed {
+			result = "rejected"
+		}
+		m.preflight.Add(ctx, 1, metric.WithAttributes(host, attribute.String("result", result)))
+	}
+	if allowed {
+		m.allowed.Add(ctx, 1, metric.WithAttributes(host))
+		return
+	}
+	m.rejected.Add(ctx, 1, metric.WithAttributes(host))
+}
+
+func (m *metrics) originHost(origin string) string {
+	u, err := url.Parse(origin)
+	if err != nil || u.Hostname() == "" {
+		return "invalid"
+	}
+	host := u.Hostname()
+	m.m.Lock()
+	defer m.m.Unlock()
+	if _, ok := m.hosts[host]; ok {
+		return host
+	}
+	if len(m.hosts) >= maxOriginHosts {
+		return "other"
+	}
+	m.hosts[host] = struct{}{}
+	return host
+}
@@ -0,0 +1,290 @@
+package cors
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/metric"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	DefaultMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+	DefaultHeaders = []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With"}
+)
+
+type Option func(o *options)
+
+type originMaxAge struct {
+	origin string
+	maxAge time.Duration
+}
+
+// CorsConfig is the CORS section of the configuration, implemented by *cors.CorsOption.
+type CorsConfig interface {
+	GetOrigins() []string
+	GetOriginPatterns() []string
+	GetMethods() []string
+	GetHeaders() []string
+	GetAllowCredentials() bool
+	GetMaxAge() int32
+	GetOriginMaxAge() map[string]int32
+	GetOptionsPassthrough() bool
+	GetOptionsSuccessStatus() int32
+	CorsProfiles() map[string]CorsConfig
+}
+
+type options struct {
+	allowedOrigins   []string
+	originPatterns   []*regexp.Regexp
+	originSources    []*OriginSource
+	legacyWildcard   bool
+	strict           bool
+	forwardedProto   bool
+	passthrough      bool
+	successStatus    int
+	profileOpts      map[string][]Option
+	profiles         map[string]*options
+	allowOriginFunc  func(ctx context.Context, origin string) bool
+	allowedMethods   []string
+	allowedHeaders   []string
+	exposedHeaders   []string
+	allowCredentials bool
+	maxAge           time.Duration
+	originMaxAge     []originMaxAge
+	meterProvider    metric.MeterProvider
+	metrics          *metrics
+	logger           log.Logger
+	log              *log.Helper
+	errs             []error
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		successStatus:  http.StatusNoContent,
+		allowedMethods: slices.Clone(DefaultMethods),
+		allowedHeaders: slices.Clone(DefaultHeaders),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.allowedOrigins) == 0 && len(o.originPatterns) == 0 && len(o.originSources) == 0 && o.allowOriginFunc == nil {
+		o.allowedOrigins = []string{"*"}
+	}
+	o.metrics = newMetrics(o.meterProvider)
+	if o.logger == nil {
+		o.logger = log.GetLogger()
+	}
+	o.log = log.NewHelper(o.logger)
+	o.profiles = make(map[string]*options, len(o.profileOpts))
+	for host, opts := range o.profileOpts {
+		o.profiles[host] = newOptions(append([]Option{WithLogger(o.logger), WithMeterProvider(o.meterProvider), WithForwardedProto(o.forwardedProto)}, opts...)...)
+	}
+	return o
+}
+
+// mustNewOptions is newOptions for the constructors without an error result. Invalid
+// options panic at startup instead of silently weakening or breaking the policy.
+func mustNewOptions(opts ...Option) *options {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// WithAllowedOrigins sets the allowed origins: exact origins, "*" for any origin,
+// "https://*.example.com" for any subdomain on that scheme and port, or
+// "http://localhost:*" for any port of a loopback host. A subdomain wildcard without
+// scheme, "*.example.com", matches https subdomains.
+func WithAllowedOrigins(origins ...string) Option {
+	return func(o *options) {
+		o.allowedOrigins = origins
+	}
+}
+
+// WithAllowedOriginPatterns allows origins matching any of the regular expressions,
+// e.g. ^https://pr-\d+\.preview\.example\.com$. Invalid expressions make the
+// constructors panic and ServerWithConfig fail.
+func WithAllowedOriginPatterns(patterns ...string) Option {
+	return func(o *options) {
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				o.errs = append(o.errs, fmt.Errorf("invalid origin pattern %q: %w", pattern, err))
+				continue
+			}
+			o.originPatterns = append(o.originPatterns, re)
+		}
+	}
+}
+
+// WithStrictMode rejects requests from disallowed origins with 403 and an error
+// explaining the rejection, instead of only omitting the CORS headers. Same-origin
+// requests, whose Origin matches the scheme and Host of the request, are never
+// rejected; see WithForwardedProto behind a TLS terminating proxy.
+func WithStrictMode(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+// WithForwardedProto takes the scheme of a request from its X-Forwarded-Proto header
+// when deciding whether it is same-origin, for services behind a TLS terminating
+// proxy. Only enable it when the proxy overwrites the header.
+func WithForwardedProto(trust bool) Option {
+	return func(o *options) {
+		o.forwardedProto = trust
+	}
+}
+
+// WithOptionsPassthrough passes allowed preflight requests on to the application
+// after the CORS headers are written, instead of answering them.
+func WithOptionsPassthrough(passthrough bool) Option {
+	return func(o *options) {
+		o.passthrough = passthrough
+	}
+}
+
+// WithOptionsSuccessStatus sets the status of answered preflight requests, 204 by
+// default; some legacy clients need 200.
+func WithOptionsSuccessStatus(status int) Option {
+	return func(o *options) {
+		o.successStatus = status
+	}
+}
+
+// WithLegacyWildcardMatching restores the matching of wildcards without scheme,
+// like "*.example.com", against subdomains on any scheme. As before, origins with
+// an explicit port don't match.
+func WithLegacyWildcardMatching(legacy bool) Option {
+	return func(o *options) {
+		o.legacyWildcard = legacy
+	}
+}
+
+// WithOriginSource additionally allows the origins currently loaded by source.
+// The source must be started, e.g. registered as a kratos server.
+func WithOriginSource(source *OriginSource) Option {
+	return func(o *options) {
+		o.originSources = append(o.originSources, source)
+	}
+}
+
+// WithAllowOriginFunc consults fn at request time for origins the static lists don't
+// allow, e.g. to look up tenant origins in a database.
+func WithAllowOriginFunc(fn func(ctx context.Context, origin string) bool) Option {
+	return func(o *options) {
+		o.allowOriginFunc = fn
+	}
+}
+
+func WithAllowedMethods(methods ...string) Option {
+	return func(o *options) {
+		o.allowedMethods = methods
+	}
+}
+
+// WithAllowedHeaders sets the request headers allowed in preflights, matched
+// case-insensitively; "*" allows any header.
+func WithAllowedHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.allowedHeaders = headers
+	}
+}
+
+func WithExposedHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.exposedHeaders = headers
+	}
+}
+
+func WithAllowCredentials(allow bool) Option {
+	return func(o *options) {
+		o.allowCredentials = allow
+	}
+}
+
+// WithMaxAge sets how long browsers may cache preflight results.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = maxAge
+	}
+}
+
+// WithOriginMaxAge overrides the max age for origins matching any of the rules, which
+// take the forms of WithAllowedOrigins. The first matching override wins.
+func WithOriginMaxAge(maxAge time.Duration, origins ...string) Option {
+	return func(o *options) {
+		for _, origin := range origins {
+			o.originMaxAge = append(o.originMaxAge, originMaxAge{origin: origin, maxAge: maxAge})
+		}
+	}
+}
+
+// WithHostProfile applies a separate policy, built from opts only, to requests for
+// host, e.g. to serve api.example.com and admin.example.com from one binary.
+func WithHostProfile(host string, opts ...Option) Option {
+	return func(o *options) {
+		if o.profileOpts == nil {
+			o.profileOpts = make(map[string][]Option)
+		}
+		o.profileOpts[strings.ToLower(host)] = opts
+	}
+}
+
+// WithLogger sets the logger warning about rejected origins, the global one by default.
+func WithLogger(logger log.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMeterProvider sets the provider of the CORS decision counters, the global one by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		o.meterProvider = mp
+	}
+}
+
+// WithCorsOption applies the CORS section of the configuration.
+func WithCorsOption(opt CorsConfig) Option {
+	return func(o *options) {
+		if len(opt.GetOrigins()) > 0 {
+			o.allowedOrigins = opt.GetOrigins()
+		}
+		if len(opt.GetMethods()) > 0 {
+			o.allowedMethods = opt.GetMethods()
+		}
+		if len(opt.GetHeaders()) > 0 {
+			o.allowedHeaders = opt.GetHeaders()
+		}
+		WithAllowedOriginPatterns(opt.GetOriginPatterns()...)(o)
+		if opt.GetMaxAge() > 0 {
+			o.maxAge = time.Duration(opt.GetMaxAge()) * time.Second
+		}
+		origins := make([]string, 0, len(opt.GetOriginMaxAge()))
+		for origin := range opt.GetOriginMaxAge() {
+			origins = append(origins, origin)
+		}
+		// map order is random, longer rules are more specific
+		slices.SortFunc(origins, func(a, b string) int { return len(b) - len(a) })
+		for _, origin := range origins {
+			WithOriginMaxAge(time.Duration(opt.GetOriginMaxAge()[origin])*time.Second, origin)(o)
+		}
+		if opt.GetOptionsPassthrough() {
+			o.passthrough = true
+		}
+		if opt.GetOptionsSuccessStatus() > 0 {
+			o.successStatus = int(opt.GetOptionsSuccessStatus())
+		}
+		for host, profile := range opt.CorsProfiles() {
+			WithHostProfile(host, WithCorsOption(profile))(o)
+		}
+		o.allowCredentials = opt.GetAllowCredentials()
+	}
+}
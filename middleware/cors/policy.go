@@ -0,0 +1,34 @@
+package cors
+
+import (
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"net/http"
+)
+
+// Policy applies the CORS options to plain net/http requests, for frameworks without
+// a kratos or net/http middleware chain. The gin and echo middleware in the
+// contrib/cors/gincors and contrib/cors/echocors modules are built on it.
+type Policy struct {
+	o *options
+}
+
+// NewPolicy panics on invalid options; check them with ValidateConfig beforehand when
+// they come from user input.
+func NewPolicy(opts ...Option) *Policy {
+	return &Policy{o: mustNewOptions(opts...)}
+}
+
+// Apply writes the CORS headers of req to w. It reports whether the request was
+// answered, a preflight or a strict mode rejection, and must not reach the application.
+func (p *Policy) Apply(w http.ResponseWriter, req *http.Request) bool {
+	done, err := p.o.handle(req.Context(), req, req.Header, w.Header())
+	if err != nil {
+		khttp.DefaultErrorEncoder(w, req, err)
+		return true
+	}
+	if done && !p.o.passthrough {
+		w.WriteHeader(p.o.successStatus)
+		return true
+	}
+	return false
+}
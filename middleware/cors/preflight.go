@@ -0,0 +1,33 @@
+package cors
+
+import (
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// RegisterPreflight registers OPTIONS routes on srv answering preflight requests with
+// the default CORS options, for services that only define GET/POST routes and don't
+// install Filter. Without paths a catch-all route is registered. Use
+// Policy.RegisterPreflight for other options.
+func RegisterPreflight(srv *khttp.Server, paths ...string) {
+	NewPolicy().RegisterPreflight(srv, paths...)
+}
+
+// RegisterPreflight registers OPTIONS routes on srv answering preflight requests with
+// the CORS headers and the options success status of the policy.
+func (p *Policy) RegisterPreflight(srv *khttp.Server, paths ...string) {
+	if len(paths) == 0 {
+		paths = []string{"/{path:.*}"}
+	}
+	handler := func(ctx khttp.Context) error {
+		req, res := ctx.Request(), ctx.Response()
+		if _, err := p.o.handle(req.Context(), req, req.Header, res.Header()); err != nil {
+			return err
+		}
+		res.WriteHeader(p.o.successStatus)
+		return nil
+	}
+	route := srv.Route("/")
+	for _, path := range paths {
+		route.OPTIONS(path, handler)
+	}
+}
@@ -0,0 +1,43 @@
+package cors
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Development returns permissive options for local development: any loopback origin
+// on any port, any request header and credentials.
+func Development() []Option {
+	return []Option{
+		WithAllowedOrigins(
+			"http://localhost:*", "https://localhost:*",
+			"http://127.0.0.1:*", "https://127.0.0.1:*",
+			"http://[::1]:*", "https://[::1]:*",
+		),
+		WithAllowedHeaders("*"),
+		WithAllowCredentials(true),
+		WithMaxAge(time.Minute),
+	}
+}
+
+// Production returns options allowing credentialed requests from exactly origins, with
+// the default methods and headers and preflights cached for an hour. Origins must be
+// neither empty nor "*", the constructors panic otherwise.
+func Production(origins ...string) []Option {
+	return []Option{
+		requireExplicitOrigins(origins),
+		WithAllowedOrigins(origins...),
+		WithAllowCredentials(true),
+		WithMaxAge(time.Hour),
+	}
+}
+
+// requireExplicitOrigins rejects an empty origin list, which falls back to "*", and "*".
+func requireExplicitOrigins(origins []string) Option {
+	return func(o *options) {
+		if len(origins) == 0 || slices.Contains(origins, "*") {
+			o.errs = append(o.errs, fmt.Errorf("cors: production presets need explicit origins, got %q", origins))
+		}
+	}
+}
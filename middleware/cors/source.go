@@ -0,0 +1,179 @@
+package cors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ transport.Server = (*OriginSource)(nil)
+)
+
+const defaultSourceInterval = time.Minute
+
+// OriginLoader loads a list of allowed origins.
+type OriginLoader interface {
+	Load(ctx context.Context) ([]string, error)
+}
+
+// OriginLoaderFunc adapts a function to OriginLoader.
+type OriginLoaderFunc func(ctx context.Context) ([]string, error)
+
+func (f OriginLoaderFunc) Load(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// FileOrigins reads origins from a local file, see ParseOrigins for the format.
+func FileOrigins(path string) OriginLoader {
+	return OriginLoaderFunc(func(ctx context.Context) ([]string, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return ParseOrigins(data)
+	})
+}
+
+// URLOrigins fetches origins from an HTTP(S) endpoint, see ParseOrigins for the format.
+// A nil client uses a client with a 10s timeout.
+func URLOrigins(url string, client *http.Client) OriginLoader {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return OriginLoaderFunc(func(ctx context.Context) ([]string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetch origins from %s: unexpected status %s", url, resp.Status)
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return nil, err
+		}
+		return ParseOrigins(data)
+	})
+}
+
+// ParseOrigins parses a JSON array of origins, or one origin per line where blank
+// lines and lines starting with # are ignored. Entries support the same forms as
+// WithAllowedOrigins.
+func ParseOrigins(data []byte) ([]string, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var origins []string
+		if err := json.Unmarshal(data, &origins); err != nil {
+			return nil, err
+		}
+		return origins, nil
+	}
+	var origins []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		origins = append(origins, line)
+	}
+	return origins, scanner.Err()
+}
+
+// OriginSource refreshes a list of allowed origins from a loader on an interval.
+// A failed refresh keeps the last loaded list.
+type OriginSource struct {
+	loader   OriginLoader
+	interval time.Duration
+	origins  *atomic.Pointer[[]string]
+	log      *log.Helper
+	stop     chan struct{}
+	once     *sync.Once
+}
+
+// NewOriginSource returns a source refreshing every interval, one minute when interval <= 0.
+func NewOriginSource(loader OriginLoader, interval time.Duration, logger log.Logger) *OriginSource {
+	if interval <= 0 {
+		interval = defaultSourceInterval
+	}
+	return &OriginSource{
+		loader:   loader,
+		interval: interval,
+		origins:  &atomic.Pointer[[]string]{},
+		log:      log.NewHelper(logger),
+		stop:     make(chan struct{}),
+		once:     &sync.Once{},
+	}
+}
+
+// Start loads the origins, then refreshes them until Stop.
+func (s *OriginSource) Start(ctx context.Context) error {
+	if err := s.Refresh(ctx); err != nil {
+		s.log.Errorf("load cors origins error -> %s", err.Error())
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.stop:
+			return nil
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				s.log.Errorf("refresh cors origins error -> %s", err.Error())
+			}
+		}
+	}
+}
+
+func (s *OriginSource) Stop(ctx context.Context) error {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	return nil
+}
+
+// Refresh loads the origins now.
+func (s *OriginSource) Refresh(ctx context.Context) error {
+	origins, err := s.loader.Load(ctx)
+	if err != nil {
+		return err
+	}
+	s.origins.Store(&origins)
+	return nil
+}
+
+// Origins returns the last loaded origins.
+func (s *OriginSource) Origins() []string {
+	if origins := s.origins.Load(); origins != nil {
+		return *origins
+	}
+	return nil
+}
+
+func (s *OriginSource) allows(origin string, legacy bool) bool {
+	for _, allowed := range s.Origins() {
+		if matchOrigin(allowed, origin, legacy) {
+			return true
+		}
+	}
+	return false
+}
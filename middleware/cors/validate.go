@@ -0,0 +1,103 @@
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var methodToken = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
+// ValidateConfig reports every invalid setting of cfg: malformed origins and patterns,
+// invalid methods, and credentials allowed for any origin, which browsers refuse.
+func ValidateConfig(cfg CorsConfig) error {
+	var errs []error
+	for _, origin := range cfg.GetOrigins() {
+		if err := validateOrigin(origin); err != nil {
+			errs = append(errs, err)
+		}
+		if origin == "*" && cfg.GetAllowCredentials() {
+			errs = append(errs, fmt.Errorf("cors: allow_credentials can't be combined with origin \"*\""))
+		}
+	}
+	for _, pattern := range cfg.GetOriginPatterns() {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("cors: invalid origin pattern %q: %w", pattern, err))
+		}
+	}
+	if status := cfg.GetOptionsSuccessStatus(); status != 0 && (status < 200 || status > 299) {
+		errs = append(errs, fmt.Errorf("cors: options_success_status %d is not a 2xx status", status))
+	}
+	if cfg.GetMaxAge() < 0 {
+		errs = append(errs, fmt.Errorf("cors: max_age must not be negative"))
+	}
+	for origin, maxAge := range cfg.GetOriginMaxAge() {
+		if err := validateOrigin(origin); err != nil {
+			errs = append(errs, err)
+		}
+		if maxAge < 0 {
+			errs = append(errs, fmt.Errorf("cors: origin_max_age of %q must not be negative", origin))
+		}
+	}
+	for _, method := range cfg.GetMethods() {
+		if !methodToken.MatchString(method) {
+			errs = append(errs, fmt.Errorf("cors: invalid method %q", method))
+		}
+	}
+	for host, profile := range cfg.CorsProfiles() {
+		if err := ValidateConfig(profile); err != nil {
+			errs = append(errs, fmt.Errorf("cors: profile %q: %w", host, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	if !strings.Contains(origin, "://") {
+		if strings.HasPrefix(origin, "*.") && !strings.ContainsAny(origin[2:], "*/") {
+			return nil
+		}
+		return fmt.Errorf("cors: invalid origin %q: expected scheme://host[:port], \"*\" or \"*.domain\"", origin)
+	}
+	if hostname, ok := strings.CutSuffix(origin[strings.Index(origin, "://")+3:], ":*"); ok && !isLoopback(hostname) {
+		return fmt.Errorf("cors: invalid origin %q: port wildcards are limited to localhost, 127.0.0.1 and [::1]", origin)
+	}
+	u, err := url.Parse(strings.Replace(strings.TrimSuffix(origin, ":*"), "://*.", "://wildcard.", 1))
+	if err != nil {
+		return fmt.Errorf("cors: invalid origin %q: %w", origin, err)
+	}
+	if u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("cors: invalid origin %q: expected scheme://host[:port]", origin)
+	}
+	return nil
+}
+
+// validate reports the invalid settings of options built from functional options.
+func (o *options) validate() error {
+	errs := append([]error{}, o.errs...)
+	for _, rule := range o.originMaxAge {
+		if rule.maxAge < 0 {
+			errs = append(errs, fmt.Errorf("cors: max age of %q must not be negative", rule.origin))
+		}
+	}
+	if o.successStatus < 200 || o.successStatus > 299 {
+		errs = append(errs, fmt.Errorf("cors: options success status %d is not a 2xx status", o.successStatus))
+	}
+	if o.maxAge < 0 {
+		errs = append(errs, fmt.Errorf("cors: max age must not be negative"))
+	}
+	if o.allowCredentials && o.allowsAnyOrigin() {
+		errs = append(errs, fmt.Errorf("cors: allow credentials can't be combined with origin \"*\""))
+	}
+	for host, profile := range o.profiles {
+		if err := profile.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("cors: profile %q: %w", host, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Option func(o *options)
+
+type options struct {
+	exclude []string
+}
+
+// WithExcludePaths skips requests whose path or operation matches, e.g. "/health".
+// A trailing "*" matches by prefix, e.g. "/debug/*".
+func WithExcludePaths(paths ...string) Option {
+	return func(o *options) {
+		o.exclude = append(o.exclude, paths...)
+	}
+}
+
+// AccessLog logs one structured line per server request with the method, path or rpc,
+// status, latency, peer, reply size and trace id. It replaces kratos logging.Server,
+// which logs the request arguments instead of the access summary.
+func AccessLog(logger log.Logger, opts ...Option) middleware.Middleware {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			info := requestInfoFromContext(ctx)
+			if o.excluded(info.path) {
+				return handler(ctx, req)
+			}
+			start := time.Now()
+			reply, err := handler(ctx, req)
+			status := http.StatusOK
+			var reason string
+			if se := errors.FromError(err); se != nil {
+				status = int(se.Code)
+				reason = se.Reason
+			}
+			level := log.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = log.LevelError
+			}
+			_ = log.WithContext(ctx, logger).Log(level,
+				"kind", "access",
+				"component", info.kind,
+				"method", info.method,
+				"path", info.path,
+				"status", status,
+				"reason", reason,
+				"latency", time.Since(start).Seconds(),
+				"peer", info.peer,
+				"bytes", replySize(reply),
+				"trace.id", traceID(ctx),
+			)
+			return reply, err
+		}
+	}
+}
+
+type requestInfo struct {
+	kind   string
+	method string
+	path   string
+	peer   string
+}
+
+// requestInfoFromContext summarizes the server request of ctx; gRPC requests have the
+// operation as path and no method.
+func requestInfoFromContext(ctx context.Context) requestInfo {
+	var info requestInfo
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		info.kind = tr.Kind().String()
+		info.path = tr.Operation()
+		if ht, ok := tr.(khttp.Transporter); ok {
+			info.method = ht.Request().Method
+			info.path = ht.Request().URL.Path
+			info.peer = ht.Request().RemoteAddr
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		info.peer = p.Addr.String()
+	}
+	return info
+}
+
+func traceID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+func (o *options) excluded(path string) bool {
+	for _, rule := range o.exclude {
+		if prefix, ok := strings.CutSuffix(rule, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// replySize is the encoded size of protobuf replies, 0 for other replies.
+func replySize(reply interface{}) int {
+	if m, ok := reply.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
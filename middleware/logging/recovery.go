@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"github.com/cocosip/zero/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/log"
+	krecovery "github.com/go-kratos/kratos/v2/middleware/recovery"
+	"runtime"
+)
+
+// RecoveryHandler returns a handler for kratos recovery.WithHandler logging the panic
+// as one structured line with its stack, the request summary, latency and trace id.
+// The request itself is not logged since it may carry sensitive fields, only its type.
+// The returned error is classified like the recovery middleware of this module.
+func RecoveryHandler(logger log.Logger) krecovery.HandlerFunc {
+	return func(ctx context.Context, req, rerr interface{}) error {
+		buf := make([]byte, 64<<10)
+		buf = buf[:runtime.Stack(buf, false)]
+		err := recovery.Classify(rerr)
+		info := requestInfoFromContext(ctx)
+		latency, _ := ctx.Value(krecovery.Latency{}).(float64)
+		_ = log.WithContext(ctx, logger).Log(log.LevelError,
+			log.DefaultMessageKey, "recovered panic",
+			"kind", "panic",
+			"panic", fmt.Sprint(rerr),
+			"reason", err.Reason,
+			"component", info.kind,
+			"method", info.method,
+			"path", info.path,
+			"peer", info.peer,
+			"request", fmt.Sprintf("%T", req),
+			"latency", latency,
+			"trace.id", traceID(ctx),
+			"stack", string(buf),
+		)
+		return err
+	}
+}
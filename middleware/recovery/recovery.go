@@ -0,0 +1,91 @@
+package recovery
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
+	krecovery "github.com/go-kratos/kratos/v2/middleware/recovery"
+	"go.opentelemetry.io/otel/trace"
+	"runtime"
+	"strings"
+)
+
+const (
+	ReasonNilPointer    = "PANIC_NIL_POINTER"
+	ReasonOutOfRange    = "PANIC_OUT_OF_RANGE"
+	ReasonTypeAssertion = "PANIC_TYPE_ASSERTION"
+	ReasonDivideByZero  = "PANIC_DIVIDE_BY_ZERO"
+	ReasonNilMap        = "PANIC_NIL_MAP"
+	ReasonRuntime       = "PANIC_RUNTIME"
+	ReasonUnknown       = "PANIC_UNKNOWN"
+
+	// CorrelationIDKey is the error metadata key carrying the id logged with the panic.
+	CorrelationIDKey = "correlation_id"
+)
+
+// Reasoner can be implemented by custom panic values to choose their own error reason.
+type Reasoner interface {
+	Reason() string
+}
+
+// Recovery recovers panics like the kratos recovery middleware, but converts them into
+// errors whose reason tells the crash class apart and whose metadata carries a correlation
+// id that matches the log line.
+func Recovery(logger log.Logger) middleware.Middleware {
+	helper := log.NewHelper(logger)
+	return krecovery.Recovery(krecovery.WithHandler(func(ctx context.Context, _, rerr interface{}) error {
+		id := correlationID(ctx)
+		err := Classify(rerr)
+		helper.WithContext(ctx).Errorf("recovered panic %s, correlation id %s -> %v", err.Reason, id, rerr)
+		return err.WithMetadata(map[string]string{CorrelationIDKey: id})
+	}))
+}
+
+// Classify converts a recovered panic value into an internal server error with a distinct reason.
+func Classify(rerr interface{}) *errors.Error {
+	switch v := rerr.(type) {
+	case *errors.Error:
+		return errors.Clone(v)
+	case Reasoner:
+		return errors.InternalServer(v.Reason(), fmt.Sprint(v))
+	case *runtime.TypeAssertionError:
+		return errors.InternalServer(ReasonTypeAssertion, v.Error())
+	case runtime.Error:
+		msg := v.Error()
+		switch {
+		case strings.Contains(msg, "nil pointer dereference"):
+			return errors.InternalServer(ReasonNilPointer, msg)
+		case strings.Contains(msg, "out of range"):
+			return errors.InternalServer(ReasonOutOfRange, msg)
+		case strings.Contains(msg, "divide by zero"):
+			return errors.InternalServer(ReasonDivideByZero, msg)
+		case strings.Contains(msg, "nil map"):
+			return errors.InternalServer(ReasonNilMap, msg)
+		default:
+			return errors.InternalServer(ReasonRuntime, msg)
+		}
+	case error:
+		var e *errors.Error
+		if stderrors.As(v, &e) {
+			return errors.Clone(e)
+		}
+		return errors.InternalServer(ReasonUnknown, v.Error())
+	default:
+		return errors.InternalServer(ReasonUnknown, fmt.Sprint(v))
+	}
+}
+
+// correlationID prefers the trace id of the request, so the error can be found in traces too.
+func correlationID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,129 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"github.com/cocosip/zero/buildinfo"
+	zerolog "github.com/cocosip/zero/log"
+	"github.com/cocosip/zero/metrics"
+	"github.com/cocosip/zero/tracing"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	mnoop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	tnoop "go.opentelemetry.io/otel/trace/noop"
+	"io"
+	"os"
+)
+
+// Config gathers the settings the three subsystems are built from. Instance identifies the
+// service in log fields and in the resource attributes of metrics and spans.
+type Config struct {
+	Instance *registry.ServiceInstance
+	Log      *zerolog.LogOption
+	Metrics  *metrics.MetricsOption
+	Option   *ObservabilityOption
+}
+
+// Observability holds the logger, meter provider and tracer provider wired by Init.
+type Observability struct {
+	Logger         log.Logger
+	MeterProvider  metric.MeterProvider
+	TracerProvider trace.TracerProvider
+	servers        []transport.Server
+	shutdown       []func(context.Context) error
+}
+
+// Init builds logging, metrics and tracing from one config so they share the same service
+// identity, and installs the providers as the otel globals.
+func Init(cfg *Config) (*Observability, error) {
+	o := &Observability{}
+	inst := cfg.Instance
+	if inst == nil {
+		inst = &registry.ServiceInstance{}
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", inst.Name),
+		attribute.String("service.version", inst.Version),
+		attribute.String("service.instance.id", inst.ID),
+	)
+
+	if len(cfg.Log.GetOutputs()) > 0 && !cfg.Option.GetDisableLogging() {
+		logger, tee, err := zerolog.NewTeeLogger(cfg.Log, inst.ID, inst.Name, inst.Version)
+		if err != nil {
+			return nil, err
+		}
+		o.Logger = logger
+		o.servers = append(o.servers, tee)
+	} else {
+		var w io.Writer = os.Stdout
+		switch {
+		case cfg.Option.GetDisableLogging():
+			w = io.Discard
+		case cfg.Option.GetLogFile() != "":
+			w = zerolog.NewFileLoggerWithOption(cfg.Option.GetLogFile(), cfg.Log)
+		}
+		o.Logger = zerolog.NewLogger(w, inst.ID, inst.Name, inst.Version, nil, nil)
+		if c, ok := w.(io.Closer); ok {
+			o.shutdown = append(o.shutdown, func(context.Context) error {
+				return c.Close()
+			})
+		}
+	}
+
+	if cfg.Option.GetDisableMetrics() {
+		o.MeterProvider = mnoop.NewMeterProvider()
+	} else {
+		opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+		if cfg.Metrics.GetPush().GetEndpoint() != "" {
+			pusher := metrics.NewPusher(cfg.Metrics.GetPush(), o.Logger)
+			opts = append(opts, sdkmetric.WithReader(pusher.Reader()))
+			o.servers = append(o.servers, pusher)
+		}
+		mp := sdkmetric.NewMeterProvider(opts...)
+		o.MeterProvider = mp
+		o.shutdown = append(o.shutdown, mp.Shutdown)
+		if err := buildinfo.RegisterMetrics(mp.Meter("github.com/cocosip/zero")); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Option.GetDisableTracing() {
+		o.TracerProvider = tnoop.NewTracerProvider()
+	} else {
+		opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+		if cfg.Option.GetTraceFile() != "" {
+			opts = append(opts, sdktrace.WithBatcher(tracing.NewRotatingFileExporter(cfg.Option.GetTraceFile(), cfg.Log)))
+		}
+		tp := sdktrace.NewTracerProvider(opts...)
+		o.TracerProvider = tp
+		o.shutdown = append(o.shutdown, tp.Shutdown)
+	}
+
+	otel.SetMeterProvider(o.MeterProvider)
+	otel.SetTracerProvider(o.TracerProvider)
+	return o, nil
+}
+
+// Servers returns the background servers, such as the metrics pusher, to add to the kratos app.
+func (o *Observability) Servers() []transport.Server {
+	return o.servers
+}
+
+// Shutdown flushes and closes every subsystem.
+func (o *Observability) Shutdown(ctx context.Context) error {
+	var errs []error
+	for i := len(o.shutdown) - 1; i >= 0; i-- {
+		if err := o.shutdown[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
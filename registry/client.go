@@ -4,6 +4,7 @@ import (
 	"context"
 	log2 "github.com/cocosip/zero/log"
 	zerolog "github.com/cocosip/zero/log"
+	"github.com/cocosip/zero/middleware/baggage"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware/logging"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
@@ -55,6 +56,7 @@ func (f *ClientFactory) CreateNewClient(serviceName string, creator ClientCreato
 		grpc.WithMiddleware(
 			recovery.Recovery(),
 			validate.Validator(),
+			baggage.Client(),
 			logging.Client(f._logger),
 		),
 	)
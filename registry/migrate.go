@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-kratos/kratos/v2/registry"
+	"time"
+)
+
+// Migrator copies the instances of a set of services from one registry to another.
+type Migrator struct {
+	from     registry.Discovery
+	to       registry.Registrar
+	services []string
+	copied   map[string]*registry.ServiceInstance
+}
+
+func NewMigrator(from registry.Discovery, to registry.Registrar, services ...string) *Migrator {
+	return &Migrator{
+		from:     from,
+		to:       to,
+		services: services,
+		copied:   map[string]*registry.ServiceInstance{},
+	}
+}
+
+// Copy registers every current instance in the target registry and deregisters the
+// instances copied earlier that disappeared from the source. It returns the number
+// of registered and removed instances.
+func (m *Migrator) Copy(ctx context.Context) (int, int, error) {
+	seen := map[string]bool{}
+	var registered, removed int
+	for _, name := range m.services {
+		instances, err := m.from.GetService(ctx, name)
+		if err != nil {
+			return registered, removed, fmt.Errorf("get service %s error -> %w", name, err)
+		}
+		for _, instance := range instances {
+			key := instance.Name + "/" + instance.ID
+			seen[key] = true
+			if prev, ok := m.copied[key]; ok && sameInstance(prev, instance) {
+				continue
+			}
+			if err = m.to.Register(ctx, instance); err != nil {
+				return registered, removed, fmt.Errorf("register %s error -> %w", key, err)
+			}
+			m.copied[key] = instance
+			registered++
+		}
+	}
+	for key, instance := range m.copied {
+		if seen[key] {
+			continue
+		}
+		if err := m.to.Deregister(ctx, instance); err != nil {
+			return registered, removed, fmt.Errorf("deregister %s error -> %w", key, err)
+		}
+		delete(m.copied, key)
+		removed++
+	}
+	return registered, removed, nil
+}
+
+// Sync copies repeatedly until ctx is done, keeping the target in step with the source until cutover.
+func (m *Migrator) Sync(ctx context.Context, interval time.Duration, report func(registered, removed int, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		registered, removed, err := m.Copy(ctx)
+		if report != nil {
+			report(registered, removed, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func sameInstance(a, b *registry.ServiceInstance) bool {
+	if a.Version != b.Version || len(a.Endpoints) != len(b.Endpoints) {
+		return false
+	}
+	for i := range a.Endpoints {
+		if a.Endpoints[i] != b.Endpoints[i] {
+			return false
+		}
+	}
+	return true
+}
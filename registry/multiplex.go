@@ -0,0 +1,167 @@
+package registry
+
+import (
+	"context"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+	"sync"
+	"time"
+)
+
+var (
+	_ registry.Discovery = (*MultiplexDiscovery)(nil)
+	_ registry.Watcher   = (*muxWatcher)(nil)
+)
+
+// MultiplexDiscovery shares one upstream watcher per service between every local
+// watcher of that service, so many clients in a process don't each poll the backend.
+type MultiplexDiscovery struct {
+	dis       registry.Discovery
+	upstreams map[string]*muxUpstream
+	log       *log.Helper
+	m         *sync.Mutex
+}
+
+func NewMultiplexDiscovery(dis registry.Discovery, logger log.Logger) *MultiplexDiscovery {
+	return &MultiplexDiscovery{
+		dis:       dis,
+		upstreams: map[string]*muxUpstream{},
+		log:       log.NewHelper(logger),
+		m:         &sync.Mutex{},
+	}
+}
+
+func (d *MultiplexDiscovery) GetService(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	return d.dis.GetService(ctx, name)
+}
+
+func (d *MultiplexDiscovery) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	up, ok := d.upstreams[name]
+	if !ok {
+		upCtx, cancel := context.WithCancel(context.Background())
+		w, err := d.dis.Watch(upCtx, name)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		up = &muxUpstream{
+			name:        name,
+			watcher:     w,
+			cancel:      cancel,
+			subscribers: map[*muxWatcher]struct{}{},
+			m:           &sync.Mutex{},
+		}
+		d.upstreams[name] = up
+		go d.run(upCtx, up)
+	}
+	sub := &muxWatcher{
+		mux:    d,
+		up:     up,
+		notify: make(chan struct{}, 1),
+		once:   &sync.Once{},
+	}
+	sub.ctx, sub.cancel = context.WithCancel(ctx)
+	up.m.Lock()
+	up.subscribers[sub] = struct{}{}
+	up.m.Unlock()
+	return sub, nil
+}
+
+func (d *MultiplexDiscovery) run(ctx context.Context, up *muxUpstream) {
+	for {
+		items, err := up.watcher.Next()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			d.log.Errorf("watch service <%s> error -> %s", up.name, err.Error())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+		up.publish(items)
+	}
+}
+
+func (d *MultiplexDiscovery) unsubscribe(sub *muxWatcher) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	up := sub.up
+	up.m.Lock()
+	delete(up.subscribers, sub)
+	empty := len(up.subscribers) == 0
+	up.m.Unlock()
+	if !empty || d.upstreams[up.name] != up {
+		return
+	}
+	delete(d.upstreams, up.name)
+	up.cancel()
+	if err := up.watcher.Stop(); err != nil {
+		d.log.Errorf("stop watcher of service <%s> error -> %s", up.name, err.Error())
+	}
+}
+
+type muxUpstream struct {
+	name        string
+	watcher     registry.Watcher
+	cancel      context.CancelFunc
+	latest      []*registry.ServiceInstance
+	seq         uint64
+	subscribers map[*muxWatcher]struct{}
+	m           *sync.Mutex
+}
+
+func (up *muxUpstream) publish(items []*registry.ServiceInstance) {
+	up.m.Lock()
+	defer up.m.Unlock()
+	up.latest = items
+	up.seq++
+	for sub := range up.subscribers {
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+type muxWatcher struct {
+	mux    *MultiplexDiscovery
+	up     *muxUpstream
+	seen   uint64
+	notify chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   *sync.Once
+}
+
+// Next returns the latest instances the subscriber hasn't seen yet, waiting for the upstream when there are none.
+func (w *muxWatcher) Next() ([]*registry.ServiceInstance, error) {
+	for {
+		w.up.m.Lock()
+		if w.up.seq > w.seen {
+			w.seen = w.up.seq
+			items := w.up.latest
+			w.up.m.Unlock()
+			return items, nil
+		}
+		w.up.m.Unlock()
+		select {
+		case <-w.ctx.Done():
+			return nil, w.ctx.Err()
+		case <-w.notify:
+		}
+	}
+}
+
+func (w *muxWatcher) Stop() error {
+	w.once.Do(func() {
+		w.cancel()
+		w.mux.unsubscribe(w)
+	})
+	return nil
+}
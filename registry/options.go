@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OptionBuilder assembles a RegistryOption fluently, e.g.
+//
+//	opt, err := registry.Options().Provider("etcd").Endpoints("127.0.0.1:2379").Build()
+type OptionBuilder struct {
+	opt *RegistryOption
+}
+
+func Options() *OptionBuilder {
+	return &OptionBuilder{opt: &RegistryOption{}}
+}
+
+func (b *OptionBuilder) Provider(provider string) *OptionBuilder {
+	b.opt.Provider = provider
+	return b
+}
+
+func (b *OptionBuilder) Authority(authority string) *OptionBuilder {
+	b.opt.Authority = authority
+	return b
+}
+
+// Endpoints sets the etcd endpoints.
+func (b *OptionBuilder) Endpoints(endpoints ...string) *OptionBuilder {
+	b.etcd().Endpoints = append(b.etcd().Endpoints, endpoints...)
+	return b
+}
+
+// Credentials sets the etcd username and password.
+func (b *OptionBuilder) Credentials(username, password string) *OptionBuilder {
+	b.etcd().Username = username
+	b.etcd().Password = password
+	return b
+}
+
+// Entry adds a static entry to the local registry.
+func (b *OptionBuilder) Entry(id, name, version string, endpoints ...string) *OptionBuilder {
+	b.local().Entries = append(b.local().Entries, &RegistryOption_LocalOption_Entry{
+		Id:        id,
+		Name:      name,
+		Version:   version,
+		Endpoints: endpoints,
+	})
+	return b
+}
+
+func (b *OptionBuilder) PollInterval(interval time.Duration) *OptionBuilder {
+	b.local().PollInterval = int32(interval / time.Second)
+	return b
+}
+
+func (b *OptionBuilder) TTL(ttl time.Duration) *OptionBuilder {
+	b.local().Ttl = int32(ttl / time.Second)
+	return b
+}
+
+func (b *OptionBuilder) ReadOnly(readOnly bool) *OptionBuilder {
+	b.local().ReadOnly = readOnly
+	return b
+}
+
+func (b *OptionBuilder) Namespace(namespace string) *OptionBuilder {
+	b.local().Namespace = namespace
+	return b
+}
+
+func (b *OptionBuilder) HealthyOnly(healthyOnly bool) *OptionBuilder {
+	b.local().HealthyOnly = healthyOnly
+	return b
+}
+
+// Ordering sets how the local registry orders instances: none, shuffle or round_robin.
+func (b *OptionBuilder) Ordering(ordering string) *OptionBuilder {
+	b.local().Ordering = ordering
+	return b
+}
+
+// Build validates and returns the assembled option.
+func (b *OptionBuilder) Build() (*RegistryOption, error) {
+	if err := Validate(b.opt); err != nil {
+		return nil, err
+	}
+	return b.opt, nil
+}
+
+func (b *OptionBuilder) local() *RegistryOption_LocalOption {
+	if b.opt.Local == nil {
+		b.opt.Local = &RegistryOption_LocalOption{}
+	}
+	return b.opt.Local
+}
+
+func (b *OptionBuilder) etcd() *RegistryOption_EtcdOption {
+	if b.opt.Etcd == nil {
+		b.opt.Etcd = &RegistryOption_EtcdOption{}
+	}
+	return b.opt.Etcd
+}
+
+// Validate reports every invalid setting of opt.
+func Validate(opt *RegistryOption) error {
+	var errs []error
+	switch strings.ToLower(opt.GetProvider()) {
+	case "local":
+		if opt.GetLocal() == nil {
+			errs = append(errs, fmt.Errorf("registry.local: required by provider local"))
+		}
+		for i, e := range opt.GetLocal().GetEntries() {
+			if strings.TrimSpace(e.GetName()) == "" {
+				errs = append(errs, fmt.Errorf("registry.local.entries[%d].name: required", i))
+			}
+		}
+		if opt.GetLocal().GetPollInterval() < 0 || opt.GetLocal().GetTtl() < 0 {
+			errs = append(errs, fmt.Errorf("registry.local: poll_interval and ttl must not be negative"))
+		}
+		switch strings.ToLower(opt.GetLocal().GetOrdering()) {
+		case "", "none", "shuffle", "round_robin":
+		default:
+			errs = append(errs, fmt.Errorf("registry.local.ordering: unknown ordering %q", opt.GetLocal().GetOrdering()))
+		}
+	case "etcd":
+		if len(opt.GetEtcd().GetEndpoints()) == 0 {
+			errs = append(errs, fmt.Errorf("registry.etcd.endpoints: required by provider etcd"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("registry.provider: unknown provider %q", opt.GetProvider()))
+	}
+	return errors.Join(errs...)
+}
@@ -1,13 +1,18 @@
 package registry
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/cocosip/zero/buildinfo"
 	"github.com/cocosip/zero/contrib/registry/local"
 	"github.com/go-kratos/kratos/contrib/registry/etcd/v2"
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/registry"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"strings"
 	"sync"
+	"time"
 )
 
 type DiscoveryRegistrar interface {
@@ -15,24 +20,64 @@ type DiscoveryRegistrar interface {
 	registry.Registrar
 }
 
+// ServiceLister is implemented by backends that can enumerate service names, and by the
+// factory returned from New:
+//
+//	names, err := f.(registry.ServiceLister).ListServices(ctx)
+type ServiceLister interface {
+	ListServices(ctx context.Context) ([]string, error)
+}
+
+var ErrListUnsupported = errors.New("registry backend does not support listing services")
+
 type FactoryInterface interface {
 	GetRegister() (registry.Registrar, error)
 	GetDiscovery() (registry.Discovery, error)
 }
 
+var _ ServiceLister = (*factory)(nil)
+
 type factory struct {
-	opt *RegistryOption
-	reg DiscoveryRegistrar
-	m   *sync.Mutex
+	opt       *RegistryOption
+	logger    log.Logger
+	multiplex bool
+	reg       DiscoveryRegistrar
+	dis       *MultiplexDiscovery
+	m         *sync.Mutex
+}
+
+type FactoryOption func(f *factory)
+
+// WithLogger sets the logger of the factory and the registries it builds, the global
+// kratos logger by default.
+func WithLogger(logger log.Logger) FactoryOption {
+	return func(f *factory) {
+		if logger != nil {
+			f.logger = logger
+		}
+	}
 }
 
-func New(opt *RegistryOption) FactoryInterface {
-	return &factory{
-		opt: opt,
-		m:   &sync.Mutex{},
+// WithMultiplex makes GetDiscovery share one upstream watcher per service between all
+// watchers of the process, see MultiplexDiscovery.
+func WithMultiplex(multiplex bool) FactoryOption {
+	return func(f *factory) {
+		f.multiplex = multiplex
 	}
 }
 
+func New(opt *RegistryOption, opts ...FactoryOption) FactoryInterface {
+	f := &factory{
+		opt:    opt,
+		logger: log.GetLogger(),
+		m:      &sync.Mutex{},
+	}
+	for _, o := range opts {
+		o(f)
+	}
+	return f
+}
+
 func (f *factory) GetRegister() (registry.Registrar, error) {
 	reg, err := f.getRegistry()
 	if err != nil {
@@ -41,12 +86,35 @@ func (f *factory) GetRegister() (registry.Registrar, error) {
 	return reg, nil
 }
 
+// GetDiscovery returns the registry, or with WithMultiplex a discovery whose watchers
+// share one upstream watcher per service.
 func (f *factory) GetDiscovery() (registry.Discovery, error) {
 	reg, err := f.getRegistry()
 	if err != nil {
 		return nil, err
 	}
-	return reg, nil
+	if !f.multiplex {
+		return reg, nil
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.dis == nil {
+		f.dis = NewMultiplexDiscovery(reg, f.logger)
+	}
+	return f.dis, nil
+}
+
+// ListServices lists the services of the backend, or returns ErrListUnsupported.
+func (f *factory) ListServices(ctx context.Context) ([]string, error) {
+	reg, err := f.getRegistry()
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := reg.(ServiceLister)
+	if !ok {
+		return nil, ErrListUnsupported
+	}
+	return lister.ListServices(ctx)
 }
 
 func (f *factory) getRegistry() (DiscoveryRegistrar, error) {
@@ -71,7 +139,17 @@ func (f *factory) getRegistry() (DiscoveryRegistrar, error) {
 			}
 			entries = append(entries, entry)
 		}
-		f.reg = local.New(f.opt.GetAuthority(), entries...)
+		f.reg = local.New(
+			f.opt.GetAuthority(),
+			local.WithEntries(entries...),
+			local.WithPollInterval(time.Duration(f.opt.Local.GetPollInterval())*time.Second),
+			local.WithTTL(time.Duration(f.opt.Local.GetTtl())*time.Second),
+			local.WithReadOnly(f.opt.Local.GetReadOnly()),
+			local.WithHealthyOnly(f.opt.Local.GetHealthyOnly()),
+			local.WithNamespace(f.opt.Local.GetNamespace()),
+			local.WithOrdering(parseOrdering(f.opt.Local.GetOrdering())),
+			local.WithLogger(f.logger),
+		)
 	case "etcd":
 		client, err := clientv3.New(clientv3.Config{
 			Endpoints: f.opt.Etcd.GetEndpoints(),
@@ -85,7 +163,19 @@ func (f *factory) getRegistry() (DiscoveryRegistrar, error) {
 	}
 
 	if f.reg != nil {
+		buildinfo.Enable("registry", f.opt.GetProvider())
 		return f.reg, nil
 	}
 	return nil, fmt.Errorf("invalid registry %s", f.opt.GetProvider())
 }
+
+func parseOrdering(s string) local.Ordering {
+	switch strings.ToLower(s) {
+	case "shuffle":
+		return local.OrderShuffle
+	case "round_robin":
+		return local.OrderRoundRobin
+	default:
+		return local.OrderNone
+	}
+}
@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"context"
+	"github.com/cocosip/zero/contrib/registry/local"
+	"github.com/go-kratos/kratos/v2/registry"
+	"testing"
+)
+
+func newLocal(t *testing.T, opt *RegistryOption_LocalOption) (FactoryInterface, *local.Registry) {
+	t.Helper()
+	f := New(&RegistryOption{Provider: "local", Authority: "test", Local: opt})
+	reg, err := f.GetRegister()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, reg.(*local.Registry)
+}
+
+func register(t *testing.T, reg registry.Registrar, id string, endpoints ...string) {
+	t.Helper()
+	err := reg.Register(context.Background(), &registry.ServiceInstance{ID: id, Name: "orders", Version: "v1", Endpoints: endpoints})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFactoryHealthyOnly(t *testing.T) {
+	for _, healthyOnly := range []bool{false, true} {
+		_, reg := newLocal(t, &RegistryOption_LocalOption{HealthyOnly: healthyOnly})
+		register(t, reg, "orders-1", "grpc://10.0.0.1:9000")
+		if err := reg.SetHealth(context.Background(), "orders-1", local.StatusDown); err != nil {
+			t.Fatal(err)
+		}
+		items, err := reg.GetService(context.Background(), "orders")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := map[bool]int{false: 1, true: 0}[healthyOnly]; len(items) != want {
+			t.Fatalf("healthy_only=%v: got %d instances, want %d", healthyOnly, len(items), want)
+		}
+	}
+}
+
+func TestFactoryNamespace(t *testing.T) {
+	_, reg := newLocal(t, &RegistryOption_LocalOption{Namespace: "staging"})
+	register(t, reg, "orders-1", "grpc://10.0.0.1:9000")
+	items, err := reg.GetService(context.Background(), "orders")
+	if err != nil || len(items) != 1 {
+		t.Fatalf("staging: got %d instances, err %v", len(items), err)
+	}
+	items, err = reg.Namespace("").GetService(context.Background(), "orders")
+	if err != nil || len(items) != 0 {
+		t.Fatalf("default namespace sees staging instances: %d, err %v", len(items), err)
+	}
+}
+
+func TestFactoryOrdering(t *testing.T) {
+	tests := []struct {
+		ordering string
+		rotates  bool
+	}{
+		{"", false},
+		{"none", false},
+		{"round_robin", true},
+		{"ROUND_ROBIN", true},
+	}
+	for _, tt := range tests {
+		_, reg := newLocal(t, &RegistryOption_LocalOption{Ordering: tt.ordering})
+		register(t, reg, "orders-1", "grpc://10.0.0.1:9000", "grpc://10.0.0.2:9000")
+		var first []string
+		for i := 0; i < 2; i++ {
+			items, err := reg.GetService(context.Background(), "orders")
+			if err != nil || len(items) != 1 {
+				t.Fatalf("ordering %q: got %d instances, err %v", tt.ordering, len(items), err)
+			}
+			first = append(first, items[0].Endpoints[0])
+		}
+		if rotated := first[0] != first[1]; rotated != tt.rotates {
+			t.Fatalf("ordering %q: first endpoints %v, rotates %v, want %v", tt.ordering, first, rotated, tt.rotates)
+		}
+	}
+}
+
+func TestFactoryListServices(t *testing.T) {
+	f, reg := newLocal(t, &RegistryOption_LocalOption{})
+	register(t, reg, "orders-1", "grpc://10.0.0.1:9000")
+	lister, ok := f.(ServiceLister)
+	if !ok {
+		t.Fatal("factory does not implement ServiceLister")
+	}
+	names, err := lister.ListServices(context.Background())
+	if err != nil || len(names) != 1 || names[0] != "orders" {
+		t.Fatalf("ListServices = %v, %v", names, err)
+	}
+}
+
+func TestFactoryMultiplex(t *testing.T) {
+	for _, multiplex := range []bool{false, true} {
+		f := New(&RegistryOption{Provider: "local", Authority: "test", Local: &RegistryOption_LocalOption{}}, WithMultiplex(multiplex))
+		dis, err := f.GetDiscovery()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := dis.(*MultiplexDiscovery); ok != multiplex {
+			t.Fatalf("multiplex=%v: discovery %T", multiplex, dis)
+		}
+	}
+}
+
+func TestOptionBuilderOrdering(t *testing.T) {
+	opt, err := Options().Provider("local").Authority("test").Ordering("round_robin").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opt.GetLocal().GetOrdering() != "round_robin" {
+		t.Fatalf("ordering %q", opt.GetLocal().GetOrdering())
+	}
+	if _, err := Options().Provider("local").Authority("test").Ordering("random").Build(); err == nil {
+		t.Fatal("unknown ordering built")
+	}
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	zerolog "github.com/cocosip/zero/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"io"
+	"sync"
+)
+
+var (
+	_ sdktrace.SpanExporter = (*FileExporter)(nil)
+)
+
+var (
+	ErrExporterShutdown = errors.New("file span exporter is shutdown")
+)
+
+// FileExporter writes each exported batch as one OTLP/JSON line, for sites
+// without a reachable collector. Use Replay to forward the files later.
+type FileExporter struct {
+	w        io.Writer
+	shutdown bool
+	m        *sync.Mutex
+}
+
+func NewFileExporter(w io.Writer) *FileExporter {
+	return &FileExporter{
+		w: w,
+		m: &sync.Mutex{},
+	}
+}
+
+// NewRotatingFileExporter writes spans through the same rotating file writer used for logs.
+func NewRotatingFileExporter(filename string, opt *zerolog.LogOption) *FileExporter {
+	return NewFileExporter(zerolog.NewFileLoggerWithOption(filename, opt))
+}
+
+func (e *FileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	line, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.shutdown {
+		return ErrExporterShutdown
+	}
+	_, err = e.w.Write(append(line, '\n'))
+	return err
+}
+
+func (e *FileExporter) Shutdown(_ context.Context) error {
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.shutdown {
+		return nil
+	}
+	e.shutdown = true
+	if c, ok := e.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
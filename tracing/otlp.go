@@ -0,0 +1,189 @@
+package tracing
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"strconv"
+)
+
+// The types below follow the OTLP/JSON encoding of ExportTraceServiceRequest,
+// so exported files can be replayed to any OTLP/HTTP receiver unchanged.
+
+type exportRequest struct {
+	ResourceSpans []*resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource      `json:"resource"`
+	ScopeSpans []*scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Events            []event    `json:"events,omitempty"`
+	Links             []link     `json:"links,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type event struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type link struct {
+	TraceID    string     `json:"traceId"`
+	SpanID     string     `json:"spanId"`
+	Attributes []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	DoubleValue *float64    `json:"doubleValue,omitempty"`
+	ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+func newExportRequest(spans []sdktrace.ReadOnlySpan) *exportRequest {
+	req := &exportRequest{}
+	resources := map[attribute.Distinct]*resourceSpans{}
+	scopes := map[*resourceSpans]map[string]*scopeSpans{}
+	for _, s := range spans {
+		res := s.Resource()
+		key := res.Equivalent()
+		rs, ok := resources[key]
+		if !ok {
+			rs = &resourceSpans{Resource: resource{Attributes: keyValues(res.Attributes())}}
+			resources[key] = rs
+			scopes[rs] = map[string]*scopeSpans{}
+			req.ResourceSpans = append(req.ResourceSpans, rs)
+		}
+		is := s.InstrumentationScope()
+		ss, ok := scopes[rs][is.Name+"@"+is.Version]
+		if !ok {
+			ss = &scopeSpans{Scope: scope{Name: is.Name, Version: is.Version}}
+			scopes[rs][is.Name+"@"+is.Version] = ss
+			rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		}
+		ss.Spans = append(ss.Spans, newSpan(s))
+	}
+	return req
+}
+
+func newSpan(s sdktrace.ReadOnlySpan) span {
+	sc := s.SpanContext()
+	out := span{
+		TraceID:           sc.TraceID().String(),
+		SpanID:            sc.SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(s.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+		Attributes:        keyValues(s.Attributes()),
+	}
+	if s.Parent().HasSpanID() {
+		out.ParentSpanID = s.Parent().SpanID().String()
+	}
+	for _, e := range s.Events() {
+		out.Events = append(out.Events, event{
+			TimeUnixNano: strconv.FormatInt(e.Time.UnixNano(), 10),
+			Name:         e.Name,
+			Attributes:   keyValues(e.Attributes),
+		})
+	}
+	for _, l := range s.Links() {
+		out.Links = append(out.Links, link{
+			TraceID:    l.SpanContext.TraceID().String(),
+			SpanID:     l.SpanContext.SpanID().String(),
+			Attributes: keyValues(l.Attributes),
+		})
+	}
+	switch s.Status().Code {
+	case codes.Ok:
+		out.Status = status{Code: 1}
+	case codes.Error:
+		out.Status = status{Code: 2, Message: s.Status().Description}
+	}
+	return out
+}
+
+func keyValues(attrs []attribute.KeyValue) []keyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]keyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		kvs = append(kvs, keyValue{Key: string(attr.Key), Value: newAnyValue(attr.Value)})
+	}
+	return kvs
+}
+
+func newAnyValue(v attribute.Value) anyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return anyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return anyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return anyValue{DoubleValue: &f}
+	case attribute.BOOLSLICE:
+		return arrayOf(v.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return arrayOf(v.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return arrayOf(v.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return arrayOf(v.AsStringSlice(), attribute.StringValue)
+	default:
+		s := v.Emit()
+		return anyValue{StringValue: &s}
+	}
+}
+
+func arrayOf[T any](items []T, value func(T) attribute.Value) anyValue {
+	values := make([]anyValue, 0, len(items))
+	for _, item := range items {
+		values = append(values, newAnyValue(value(item)))
+	}
+	return anyValue{ArrayValue: &arrayValue{Values: values}}
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	maxReplayLineSize = 64 * 1024 * 1024
+)
+
+// Replay posts every OTLP/JSON line read from r to the OTLP/HTTP traces endpoint,
+// e.g. http://collector:4318. It returns the number of batches sent.
+func Replay(ctx context.Context, r io.Reader, endpoint string, client *http.Client) (int, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	target := strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(target, "/v1/traces") {
+		target += "/v1/traces"
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxReplayLineSize)
+	var sent int
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := post(ctx, client, target, line); err != nil {
+			return sent, fmt.Errorf("replay batch %d error -> %w", sent+1, err)
+		}
+		sent++
+	}
+	return sent, scanner.Err()
+}
+
+func post(ctx context.Context, client *http.Client, target string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("collector returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}